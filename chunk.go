@@ -0,0 +1,107 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// CHUNKING
+//--------------------
+
+// BatchByWeight splits the slice into batches where the summed weight of
+// the values returned by weight() does not exceed the budget. Values are
+// filled into the batches in their order. A single value heavier than the
+// budget gets a batch of its own.
+func BatchByWeight[V any, W constraints.Integer | constraints.Float](ivs []V, budget W, weight func(V) W) [][]V {
+	if ivs == nil {
+		return nil
+	}
+	ovss := [][]V{}
+	var batch []V
+	var sum W
+	for _, v := range ivs {
+		w := weight(v)
+		if len(batch) > 0 && sum+w > budget {
+			ovss = append(ovss, batch)
+			batch = nil
+			sum = 0
+		}
+		batch = append(batch, v)
+		sum += w
+	}
+	if len(batch) > 0 {
+		ovss = append(ovss, batch)
+	}
+	return ovss
+}
+
+// Chunk splits the slice into chunks of n values. The last chunk may
+// contain less values. A size below one returns nil.
+func Chunk[V any](ivs []V, n int) [][]V {
+	if ivs == nil || n < 1 {
+		return nil
+	}
+	ovss := make([][]V, 0, (len(ivs)+n-1)/n)
+	for i := 0; i < len(ivs); i += n {
+		ovss = append(ovss, Subslice(ivs, i, i+n-1))
+	}
+	return ovss
+}
+
+// ChunkBy splits the slice into chunks of consecutive values. A new chunk
+// is started whenever the key returned by the key function changes. The key
+// could e.g. be a field of a struct.
+func ChunkBy[V any, K comparable](ivs []V, key func(V) K) [][]V {
+	if ivs == nil {
+		return nil
+	}
+	ovss := [][]V{}
+	if len(ivs) == 0 {
+		return ovss
+	}
+	start := 0
+	last := key(ivs[0])
+	for i := 1; i < len(ivs); i++ {
+		k := key(ivs[i])
+		if k != last {
+			ovss = append(ovss, Subslice(ivs, start, i-1))
+			start = i
+			last = k
+		}
+	}
+	return append(ovss, Subslice(ivs, start, len(ivs)-1))
+}
+
+// Pairwise returns all overlapping pairs of neighboring values as slices
+// of two values. It's a shortcut for SlidingWindow() with a size of two and
+// a step of one.
+func Pairwise[V any](ivs []V) [][]V {
+	return SlidingWindow(ivs, 2, 1)
+}
+
+// SlidingWindow returns windows of size values. Each window starts step
+// values behind the start of the previous one. Only complete windows are
+// returned. A size or step below one returns nil.
+func SlidingWindow[V any](ivs []V, size, step int) [][]V {
+	if ivs == nil || size < 1 || step < 1 {
+		return nil
+	}
+	ovss := [][]V{}
+	for i := 0; i+size <= len(ivs); i += step {
+		ovss = append(ovss, Subslice(ivs, i, i+size-1))
+	}
+	return ovss
+}
+
+// EOF
@@ -0,0 +1,245 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestBatchByWeight verifies the batching of values by a weight budget.
+func TestBatchByWeight(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	weight := func(s string) int { return len(s) }
+	tests := []struct {
+		descr  string
+		values []string
+		budget int
+		out    [][]string
+	}{
+		{
+			descr:  "Values fitting into batches",
+			values: []string{"a", "bb", "ccc", "d", "ee"},
+			budget: 3,
+			out:    [][]string{{"a", "bb"}, {"ccc"}, {"d", "ee"}},
+		}, {
+			descr:  "Value heavier than budget",
+			values: []string{"a", "bbbbb", "c"},
+			budget: 3,
+			out:    [][]string{{"a"}, {"bbbbb"}, {"c"}},
+		}, {
+			descr:  "All values in one batch",
+			values: []string{"a", "b", "c"},
+			budget: 10,
+			out:    [][]string{{"a", "b", "c"}},
+		}, {
+			descr:  "Empty slice",
+			values: []string{},
+			budget: 3,
+			out:    [][]string{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			budget: 3,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.BatchByWeight(test.values, test.budget, weight), test.out)
+	}
+}
+
+// TestChunk verifies the splitting of a slice into chunks of a fixed size.
+func TestChunk(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		n      int
+		out    [][]int
+	}{
+		{
+			descr:  "Even chunks",
+			values: []int{1, 2, 3, 4, 5, 6},
+			n:      2,
+			out:    [][]int{{1, 2}, {3, 4}, {5, 6}},
+		}, {
+			descr:  "Shorter last chunk",
+			values: []int{1, 2, 3, 4, 5},
+			n:      2,
+			out:    [][]int{{1, 2}, {3, 4}, {5}},
+		}, {
+			descr:  "Chunk larger than slice",
+			values: []int{1, 2, 3},
+			n:      5,
+			out:    [][]int{{1, 2, 3}},
+		}, {
+			descr:  "Illegal chunk size",
+			values: []int{1, 2, 3},
+			n:      0,
+			out:    nil,
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			n:      2,
+			out:    [][]int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			n:      2,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Chunk(test.values, test.n), test.out)
+	}
+}
+
+// TestChunkBy verifies the splitting of a slice whenever a key changes.
+func TestChunkBy(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	key := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		descr  string
+		values []int
+		out    [][]int
+	}{
+		{
+			descr:  "Changing keys",
+			values: []int{1, 3, 2, 4, 6, 5, 8},
+			out:    [][]int{{1, 3}, {2, 4, 6}, {5}, {8}},
+		}, {
+			descr:  "Same key for all values",
+			values: []int{2, 4, 6},
+			out:    [][]int{{2, 4, 6}},
+		}, {
+			descr:  "Single value slice",
+			values: []int{1},
+			out:    [][]int{{1}},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			out:    [][]int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.ChunkBy(test.values, key), test.out)
+	}
+}
+
+// TestPairwise verifies the creation of overlapping pairs.
+func TestPairwise(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		out    [][]int
+	}{
+		{
+			descr:  "Multiple values",
+			values: []int{1, 2, 3, 4},
+			out:    [][]int{{1, 2}, {2, 3}, {3, 4}},
+		}, {
+			descr:  "Single value slice",
+			values: []int{1},
+			out:    [][]int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Pairwise(test.values), test.out)
+	}
+}
+
+// TestSlidingWindow verifies the creation of sliding windows.
+func TestSlidingWindow(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		size   int
+		step   int
+		out    [][]int
+	}{
+		{
+			descr:  "Overlapping windows",
+			values: []int{1, 2, 3, 4, 5},
+			size:   3,
+			step:   1,
+			out:    [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		}, {
+			descr:  "Windows with larger step",
+			values: []int{1, 2, 3, 4, 5, 6, 7},
+			size:   2,
+			step:   3,
+			out:    [][]int{{1, 2}, {4, 5}},
+		}, {
+			descr:  "Incomplete windows are dropped",
+			values: []int{1, 2, 3, 4},
+			size:   3,
+			step:   2,
+			out:    [][]int{{1, 2, 3}},
+		}, {
+			descr:  "Window larger than slice",
+			values: []int{1, 2},
+			size:   3,
+			step:   1,
+			out:    [][]int{},
+		}, {
+			descr:  "Illegal step",
+			values: []int{1, 2, 3},
+			size:   2,
+			step:   0,
+			out:    nil,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			size:   2,
+			step:   1,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.SlidingWindow(test.values, test.size, test.step), test.out)
+	}
+}
+
+// EOF
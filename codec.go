@@ -0,0 +1,359 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// CONSTANTS
+//--------------------
+
+// FixedSize is a constraint for numeric types with a fixed size in memory.
+// Platform dependent types like int and uint are not included.
+type FixedSize interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		constraints.Float | constraints.Complex
+}
+
+// ErrInvalidEncoding is returned if binary data cannot be decoded.
+var ErrInvalidEncoding = errors.New("slices: invalid encoding")
+
+// ErrClosedEncoder is returned when writing to a closed JSONEncoder.
+var ErrClosedEncoder = errors.New("slices: encoder is closed")
+
+//--------------------
+// BINARY ENCODING
+//--------------------
+
+// EncodeBinary encodes the values with their fixed size in big endian
+// byte order. They are prefixed by the number of values.
+func EncodeBinary[V FixedSize](ivs []V) []byte {
+	var buf bytes.Buffer
+	buf.Write(binary.AppendUvarint(nil, uint64(len(ivs))))
+	// Writing into a buffer cannot fail for fixed size values.
+	_ = binary.Write(&buf, binary.BigEndian, ivs)
+	return buf.Bytes()
+}
+
+// DecodeBinary decodes values encoded by EncodeBinary(). Data not matching
+// the expected length returns ErrInvalidEncoding.
+func DecodeBinary[V FixedSize](data []byte) ([]V, error) {
+	r := byteReader{data: data}
+	n, ok := r.uvarint()
+	if !ok {
+		return nil, ErrInvalidEncoding
+	}
+	var v V
+	rest := uint64(len(data) - r.pos)
+	if size := uint64(binary.Size(v)); n > rest/size || n*size != rest {
+		return nil, ErrInvalidEncoding
+	}
+	ovs := make([]V, n)
+	if err := binary.Read(bytes.NewReader(data[r.pos:]), binary.BigEndian, ovs); err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	return ovs, nil
+}
+
+// EncodeVarint encodes the integers with variable length. Small absolute
+// values need less bytes. They are prefixed by the number of values.
+func EncodeVarint[V constraints.Integer](ivs []V) []byte {
+	data := binary.AppendUvarint(nil, uint64(len(ivs)))
+	for _, v := range ivs {
+		data = appendInteger(data, v)
+	}
+	return data
+}
+
+// DecodeVarint decodes integers encoded by EncodeVarint(). Invalid data
+// or values out of the range of the type return ErrInvalidEncoding.
+func DecodeVarint[V constraints.Integer](data []byte) ([]V, error) {
+	return decodeIntegers(data, func(v, _ V) V { return v })
+}
+
+// EncodeDelta encodes the integers as variable length differences to their
+// predecessors. For slices sorted in ascending order like IDs or timestamps
+// this is much more compact than the values themselves. Other slices can be
+// encoded too, only less compact.
+func EncodeDelta[V constraints.Integer](ivs []V) []byte {
+	data := binary.AppendUvarint(nil, uint64(len(ivs)))
+	var prev V
+	for _, v := range ivs {
+		// Overflows of the difference are reverted when decoding.
+		data = appendInteger(data, v-prev)
+		prev = v
+	}
+	return data
+}
+
+// DecodeDelta decodes integers encoded by EncodeDelta(). Invalid data
+// returns ErrInvalidEncoding.
+func DecodeDelta[V constraints.Integer](data []byte) ([]V, error) {
+	return decodeIntegers(data, func(d, prev V) V { return prev + d })
+}
+
+//--------------------
+// JSON ENCODING
+//--------------------
+
+// JSONEncoder writes values one by one as JSON array, so the values
+// never need to be buffered.
+type JSONEncoder[V any] struct {
+	w      io.Writer
+	count  int
+	closed bool
+}
+
+// NewJSONEncoder creates an encoder writing to w.
+func NewJSONEncoder[V any](w io.Writer) *JSONEncoder[V] {
+	return &JSONEncoder[V]{w: w}
+}
+
+// Encode writes one value as element of the array.
+func (e *JSONEncoder[V]) Encode(v V) error {
+	if e.closed {
+		return ErrClosedEncoder
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sep := []byte{','}
+	if e.count == 0 {
+		sep[0] = '['
+	}
+	if _, err := e.w.Write(append(sep, data...)); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
+
+// Close ends the array. Without any encoded value an empty array
+// is written.
+func (e *JSONEncoder[V]) Close() error {
+	if e.closed {
+		return ErrClosedEncoder
+	}
+	e.closed = true
+	end := "]"
+	if e.count == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// EncodeJSON writes all values of the iterator as JSON array to w.
+func EncodeJSON[V any](w io.Writer, it Iterator[V]) error {
+	e := NewJSONEncoder[V](w)
+	for v, ok := it(); ok; v, ok = it() {
+		if err := e.Encode(v); err != nil {
+			return err
+		}
+	}
+	return e.Close()
+}
+
+// JSONDecoder reads the elements of a JSON array one by one. Its Next()
+// method is an Iterator, so it can be passed to the iterator based
+// functions. An error ends the iteration and is returned by Err().
+type JSONDecoder[V any] struct {
+	dec     *json.Decoder
+	started bool
+	done    bool
+	err     error
+}
+
+// NewJSONDecoder creates a decoder reading from r.
+func NewJSONDecoder[V any](r io.Reader) *JSONDecoder[V] {
+	return &JSONDecoder[V]{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next element of the array and true. At the end of
+// the array or after an error it returns the default value and false.
+// A JSON null is read like an empty array.
+func (d *JSONDecoder[V]) Next() (V, bool) {
+	if d.done {
+		return exhausted[V]()
+	}
+	if !d.started {
+		d.started = true
+		tok, err := d.dec.Token()
+		if err != nil {
+			return d.fail(err)
+		}
+		if tok == nil {
+			d.done = true
+			return exhausted[V]()
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return d.fail(fmt.Errorf("slices: expected JSON array, got %v", tok))
+		}
+	}
+	if !d.dec.More() {
+		d.done = true
+		if _, err := d.dec.Token(); err != nil {
+			return d.fail(err)
+		}
+		return exhausted[V]()
+	}
+	var v V
+	if err := d.dec.Decode(&v); err != nil {
+		return d.fail(err)
+	}
+	return v, true
+}
+
+// Err returns the error which ended the iteration, if any.
+func (d *JSONDecoder[V]) Err() error {
+	return d.err
+}
+
+// fail ends the iteration with the error.
+func (d *JSONDecoder[V]) fail(err error) (V, bool) {
+	d.done = true
+	d.err = err
+	return exhausted[V]()
+}
+
+// DecodeJSON reads all elements of a JSON array from r into a slice.
+func DecodeJSON[V any](r io.Reader) ([]V, error) {
+	d := NewJSONDecoder[V](r)
+	ovs := Collect(d.Next)
+	if d.Err() != nil {
+		return nil, d.Err()
+	}
+	if ovs == nil {
+		ovs = []V{}
+	}
+	return ovs, nil
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// isSigned returns true if the integer type is signed.
+func isSigned[V constraints.Integer]() bool {
+	var v V
+	return v-1 < 0
+}
+
+// appendInteger appends a signed integer as zig-zag varint and an unsigned
+// one as uvarint.
+func appendInteger[V constraints.Integer](data []byte, v V) []byte {
+	if isSigned[V]() {
+		return binary.AppendVarint(data, int64(v))
+	}
+	return binary.AppendUvarint(data, uint64(v))
+}
+
+// decodeIntegers reads the count prefixed integers and combines each one
+// with its predecessor.
+func decodeIntegers[V constraints.Integer](data []byte, combine func(v, prev V) V) ([]V, error) {
+	r := byteReader{data: data}
+	n, ok := r.uvarint()
+	// Each value needs at least one byte.
+	if !ok || n > uint64(len(data)-r.pos) {
+		return nil, ErrInvalidEncoding
+	}
+	signed := isSigned[V]()
+	ovs := make([]V, n)
+	var prev V
+	for i := range ovs {
+		var v V
+		if signed {
+			x, ok := r.varint()
+			if !ok || int64(V(x)) != x {
+				return nil, ErrInvalidEncoding
+			}
+			v = V(x)
+		} else {
+			x, ok := r.uvarint()
+			if !ok || uint64(V(x)) != x {
+				return nil, ErrInvalidEncoding
+			}
+			v = V(x)
+		}
+		prev = combine(v, prev)
+		ovs[i] = prev
+	}
+	if r.pos != len(data) {
+		return nil, ErrInvalidEncoding
+	}
+	return ovs, nil
+}
+
+// byteReader reads the values of a binary encoding.
+type byteReader struct {
+	data   []byte
+	pos    int
+	failed bool
+}
+
+// byte reads one byte.
+func (r *byteReader) byte() (byte, bool) {
+	if r.pos >= len(r.data) {
+		r.failed = true
+		return 0, false
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, true
+}
+
+// float64 reads a big endian encoded float64.
+func (r *byteReader) float64() (float64, bool) {
+	if r.pos+8 > len(r.data) {
+		r.failed = true
+		return 0, false
+	}
+	f := math.Float64frombits(binary.BigEndian.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return f, true
+}
+
+// uvarint reads a variable length encoded unsigned integer.
+func (r *byteReader) uvarint() (uint64, bool) {
+	u, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.failed = true
+		return 0, false
+	}
+	r.pos += n
+	return u, true
+}
+
+// varint reads a variable length encoded signed integer.
+func (r *byteReader) varint() (int64, bool) {
+	i, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.failed = true
+		return 0, false
+	}
+	r.pos += n
+	return i, true
+}
+
+// EOF
@@ -0,0 +1,217 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestBinary verifies the fixed size binary encoding.
+func TestBinary(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	fvs := []float64{0, -1.5, math.Pi, math.Inf(1), math.MaxFloat64}
+	data := slices.EncodeBinary(fvs)
+	assert.Length(data, 1+5*8)
+	out, err := slices.DecodeBinary[float64](data)
+	assert.NoError(err)
+	assert.Equal(out, fvs)
+
+	type Celsius int16
+	cvs := []Celsius{-40, 0, 21, 100}
+	data = slices.EncodeBinary(cvs)
+	assert.Equal(data, []byte{4, 0xff, 0xd8, 0, 0, 0, 21, 0, 100})
+	cout, err := slices.DecodeBinary[Celsius](data)
+	assert.NoError(err)
+	assert.Equal(cout, cvs)
+
+	out, err = slices.DecodeBinary[float64](slices.EncodeBinary[float64](nil))
+	assert.NoError(err)
+	assert.Equal(out, []float64{})
+
+	// Invalid data.
+	_, err = slices.DecodeBinary[int16]([]byte{2, 0, 1, 0})
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	_, err = slices.DecodeBinary[int16]([]byte{1, 0, 1, 0})
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	_, err = slices.DecodeBinary[int16]([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0, 1})
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	_, err = slices.DecodeBinary[int16](nil)
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+}
+
+// TestVarint verifies the variable length encoding of integers.
+func TestVarint(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ivs := []int{0, 1, -1, 63, -64, math.MaxInt, math.MinInt}
+	data := slices.EncodeVarint(ivs)
+	assert.Equal(data[:6], []byte{7, 0, 2, 1, 126, 127})
+	out, err := slices.DecodeVarint[int](data)
+	assert.NoError(err)
+	assert.Equal(out, ivs)
+
+	uvs := []uint64{0, 127, 128, math.MaxUint64}
+	uout, err := slices.DecodeVarint[uint64](slices.EncodeVarint(uvs))
+	assert.NoError(err)
+	assert.Equal(uout, uvs)
+
+	// Values out of range of the type.
+	_, err = slices.DecodeVarint[int8](slices.EncodeVarint([]int{1, 200}))
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	_, err = slices.DecodeVarint[uint8](slices.EncodeVarint([]uint{256}))
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	// Trailing and missing data.
+	_, err = slices.DecodeVarint[int](append(slices.EncodeVarint([]int{1}), 0))
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+	_, err = slices.DecodeVarint[int]([]byte{3, 0, 0})
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+}
+
+// TestDelta verifies the delta encoding of sorted integers.
+func TestDelta(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ivs := slices.Seq(1_000_000, 1_001_000, 7)
+	data := slices.EncodeDelta(ivs)
+	assert.True(len(data) < len(slices.EncodeVarint(ivs))/2)
+	out, err := slices.DecodeDelta[int](data)
+	assert.NoError(err)
+	assert.Equal(out, ivs)
+
+	// Unsorted and extreme values are encoded too.
+	gen := generators.New(generators.FixedRand())
+	ivs = append(gen.Ints(-1000, 1000, 100), math.MinInt64, math.MaxInt64, math.MinInt64)
+	out, err = slices.DecodeDelta[int](slices.EncodeDelta(ivs))
+	assert.NoError(err)
+	assert.Equal(out, ivs)
+
+	uvs := []uint8{255, 0, 128, 129}
+	uout, err := slices.DecodeDelta[uint8](slices.EncodeDelta(uvs))
+	assert.NoError(err)
+	assert.Equal(uout, uvs)
+
+	out, err = slices.DecodeDelta[int](slices.EncodeDelta([]int{}))
+	assert.NoError(err)
+	assert.Equal(out, []int{})
+	_, err = slices.DecodeDelta[int]([]byte{})
+	assert.True(errors.Is(err, slices.ErrInvalidEncoding))
+}
+
+// TestJSONEncoder verifies the streaming encoding of JSON arrays.
+func TestJSONEncoder(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	var buf bytes.Buffer
+	err := slices.EncodeJSON(&buf, slices.Values([]string{"a", "b\"c"}))
+	assert.NoError(err)
+	assert.Equal(buf.String(), `["a","b\"c"]`)
+
+	buf.Reset()
+	err = slices.EncodeJSON(&buf, slices.Values[int](nil))
+	assert.NoError(err)
+	assert.Equal(buf.String(), `[]`)
+
+	buf.Reset()
+	e := slices.NewJSONEncoder[[]int](&buf)
+	for _, p := range slices.Collect(slices.Permutations([]int{1, 2})) {
+		assert.NoError(e.Encode(p))
+	}
+	assert.NoError(e.Close())
+	assert.Equal(buf.String(), `[[1,2],[2,1]]`)
+	assert.ErrorMatch(e.Encode([]int{3}), ".*encoder is closed.*")
+	assert.ErrorMatch(e.Close(), ".*encoder is closed.*")
+
+	ue := slices.NewJSONEncoder[float64](&buf)
+	assert.ErrorMatch(ue.Encode(math.NaN()), ".*unsupported value.*")
+}
+
+// TestJSONDecoder verifies the streaming decoding of JSON arrays.
+func TestJSONDecoder(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	type Point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	d := slices.NewJSONDecoder[Point](strings.NewReader(` [{"x":1,"y":2}, {"x":3}] `))
+	p, ok := d.Next()
+	assert.True(ok)
+	assert.Equal(p, Point{1, 2})
+	p, ok = d.Next()
+	assert.True(ok)
+	assert.Equal(p, Point{3, 0})
+	_, ok = d.Next()
+	assert.False(ok)
+	assert.NoError(d.Err())
+
+	// Decoding works with the iterator based functions.
+	td := slices.NewTDigest(slices.DefaultCompression)
+	fd := slices.NewJSONDecoder[float64](strings.NewReader(`[1, 2, 3, 4, 5]`))
+	slices.FeedTDigestFrom(td, fd.Next)
+	assert.NoError(fd.Err())
+	assert.Equal(td.Count(), uint64(5))
+
+	tests := []struct {
+		descr string
+		json  string
+		out   []int
+		err   string
+	}{
+		{"Array", `[1, 2, 3]`, []int{1, 2, 3}, ""},
+		{"Empty array", `[]`, []int{}, ""},
+		{"Null", `null`, []int{}, ""},
+		{"No array", `{"a": 1}`, nil, ".*expected JSON array.*"},
+		{"Invalid element", `[1, "two"]`, nil, ".*cannot unmarshal.*"},
+		{"Truncated array", `[1, 2`, nil, ".*end of JSON input.*"},
+		{"Empty input", ``, nil, ".*EOF.*"},
+	}
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		out, err := slices.DecodeJSON[int](strings.NewReader(test.json))
+		if test.err != "" {
+			assert.ErrorMatch(err, test.err)
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(out, test.out)
+	}
+}
+
+// TestJSONRoundTrip verifies decoding of encoded values.
+func TestJSONRoundTrip(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	gen := generators.New(generators.FixedRand())
+	ivs := gen.Words(1000)
+
+	var buf bytes.Buffer
+	assert.NoError(slices.EncodeJSON(&buf, slices.Values(ivs)))
+	out, err := slices.DecodeJSON[string](&buf)
+	assert.NoError(err)
+	assert.Equal(out, ivs)
+}
+
+// EOF
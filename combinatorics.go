@@ -0,0 +1,233 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math/big"
+)
+
+//--------------------
+// COMBINATORICS
+//--------------------
+
+// CartesianProduct returns an iterator over all slices combining one value
+// of each of the passed slices. The values of the last slice change fastest.
+// Without any slices or with one empty slice the iterator returns nothing.
+func CartesianProduct[V any](ivss ...[]V) Iterator[[]V] {
+	if len(ivss) == 0 || ContainsAny(ivss, func(vs []V) bool { return len(vs) == 0 }) {
+		return exhausted[[]V]
+	}
+	idxs := make([]int, len(ivss))
+	done := false
+	return func() ([]V, bool) {
+		if done {
+			return nil, false
+		}
+		ovs := make([]V, len(ivss))
+		for i, idx := range idxs {
+			ovs[i] = ivss[i][idx]
+		}
+		// Increment indices like an odometer.
+		done = true
+		for i := len(idxs) - 1; i >= 0; i-- {
+			idxs[i]++
+			if idxs[i] < len(ivss[i]) {
+				done = false
+				break
+			}
+			idxs[i] = 0
+		}
+		return ovs, true
+	}
+}
+
+// Combinations returns an iterator over all combinations of k values of the
+// slice. The values are taken by position, their order stays the same as in
+// the slice.
+func Combinations[V any](ivs []V, k int) Iterator[[]V] {
+	return combinations(ivs, k, false)
+}
+
+// CombinationsWithReplacement returns an iterator over all combinations of
+// k values of the slice, where each value may be chosen multiple times.
+func CombinationsWithReplacement[V any](ivs []V, k int) Iterator[[]V] {
+	return combinations(ivs, k, true)
+}
+
+// Permutations returns an iterator over all orderings of the values of the
+// slice. They are created in lexicographic order of the value positions.
+func Permutations[V any](ivs []V) Iterator[[]V] {
+	idxs := make([]int, len(ivs))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	done := false
+	return func() ([]V, bool) {
+		if done {
+			return nil, false
+		}
+		ovs := pick(ivs, idxs)
+		// Find the next permutation of the indices.
+		i := len(idxs) - 2
+		for i >= 0 && idxs[i] > idxs[i+1] {
+			i--
+		}
+		if i < 0 {
+			done = true
+			return ovs, true
+		}
+		j := len(idxs) - 1
+		for idxs[j] < idxs[i] {
+			j--
+		}
+		idxs[i], idxs[j] = idxs[j], idxs[i]
+		for l, r := i+1, len(idxs)-1; l < r; l, r = l+1, r-1 {
+			idxs[l], idxs[r] = idxs[r], idxs[l]
+		}
+		return ovs, true
+	}
+}
+
+// PowerSet returns an iterator over all subsets of the values of the slice.
+// They are ordered by size, starting with the empty one.
+func PowerSet[V any](ivs []V) Iterator[[]V] {
+	k := 0
+	it := Combinations(ivs, k)
+	return func() ([]V, bool) {
+		for k <= len(ivs) {
+			if ovs, ok := it(); ok {
+				return ovs, true
+			}
+			k++
+			it = Combinations(ivs, k)
+		}
+		return nil, false
+	}
+}
+
+//--------------------
+// COUNTING
+//--------------------
+
+// CountCartesianProduct returns the number of slices CartesianProduct()
+// will return for slices of the given lengths.
+func CountCartesianProduct(lengths ...int) *big.Int {
+	if len(lengths) == 0 {
+		return big.NewInt(0)
+	}
+	count := big.NewInt(1)
+	for _, l := range lengths {
+		count.Mul(count, big.NewInt(int64(l)))
+	}
+	return count
+}
+
+// CountCombinations returns the number of combinations Combinations() will
+// return for k out of n values.
+func CountCombinations(n, k int) *big.Int {
+	if n < 0 || k < 0 || k > n {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// CountCombinationsWithReplacement returns the number of combinations
+// CombinationsWithReplacement() will return for k out of n values.
+func CountCombinationsWithReplacement(n, k int) *big.Int {
+	switch {
+	case n < 0 || k < 0:
+		return big.NewInt(0)
+	case k == 0:
+		return big.NewInt(1)
+	}
+	return CountCombinations(n+k-1, k)
+}
+
+// CountPermutations returns the number of permutations Permutations() will
+// return for n values.
+func CountPermutations(n int) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// CountPowerSet returns the number of subsets PowerSet() will return for
+// n values.
+func CountPowerSet(n int) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Lsh(big.NewInt(1), uint(n))
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// combinations returns an iterator over the combinations of k values with
+// or without replacement.
+func combinations[V any](ivs []V, k int, replace bool) Iterator[[]V] {
+	n := len(ivs)
+	if k < 0 || (!replace && k > n) || (replace && n == 0 && k > 0) {
+		return exhausted[[]V]
+	}
+	idxs := make([]int, k)
+	if !replace {
+		for i := range idxs {
+			idxs[i] = i
+		}
+	}
+	// maxIdx returns the highest index allowed at position i.
+	maxIdx := func(i int) int {
+		if replace {
+			return n - 1
+		}
+		return n - k + i
+	}
+	done := false
+	return func() ([]V, bool) {
+		if done {
+			return nil, false
+		}
+		ovs := pick(ivs, idxs)
+		// Find the rightmost index that can be incremented.
+		i := k - 1
+		for i >= 0 && idxs[i] == maxIdx(i) {
+			i--
+		}
+		if i < 0 {
+			done = true
+			return ovs, true
+		}
+		idxs[i]++
+		for j := i + 1; j < k; j++ {
+			if replace {
+				idxs[j] = idxs[i]
+			} else {
+				idxs[j] = idxs[j-1] + 1
+			}
+		}
+		return ovs, true
+	}
+}
+
+// pick returns a new slice with the values at the given indices.
+func pick[V any](ivs []V, idxs []int) []V {
+	ovs := make([]V, len(idxs))
+	for i, idx := range idxs {
+		ovs[i] = ivs[idx]
+	}
+	return ovs
+}
+
+// EOF
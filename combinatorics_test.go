@@ -0,0 +1,230 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestCartesianProduct verifies the creation of cartesian products.
+func TestCartesianProduct(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values [][]int
+		out    [][]int
+	}{
+		{
+			descr:  "Two slices",
+			values: [][]int{{1, 2}, {3, 4, 5}},
+			out:    [][]int{{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5}},
+		}, {
+			descr:  "Three slices",
+			values: [][]int{{1}, {2, 3}, {4, 5}},
+			out:    [][]int{{1, 2, 4}, {1, 2, 5}, {1, 3, 4}, {1, 3, 5}},
+		}, {
+			descr:  "One slice",
+			values: [][]int{{1, 2}},
+			out:    [][]int{{1}, {2}},
+		}, {
+			descr:  "One empty slice",
+			values: [][]int{{1, 2}, {}},
+			out:    nil,
+		}, {
+			descr:  "No slices",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Collect(slices.CartesianProduct(test.values...)), test.out)
+		lengths := slices.Map(test.values, func(vs []int) int { return len(vs) })
+		assert.Equal(slices.CountCartesianProduct(lengths...).Int64(), int64(len(test.out)))
+	}
+}
+
+// TestCombinations verifies the creation of combinations.
+func TestCombinations(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []string
+		k      int
+		out    [][]string
+	}{
+		{
+			descr:  "Two out of four",
+			values: []string{"a", "b", "c", "d"},
+			k:      2,
+			out:    [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+		}, {
+			descr:  "All values",
+			values: []string{"a", "b", "c"},
+			k:      3,
+			out:    [][]string{{"a", "b", "c"}},
+		}, {
+			descr:  "Zero values",
+			values: []string{"a", "b", "c"},
+			k:      0,
+			out:    [][]string{{}},
+		}, {
+			descr:  "More than available",
+			values: []string{"a", "b"},
+			k:      3,
+			out:    nil,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			k:      1,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Collect(slices.Combinations(test.values, test.k)), test.out)
+		assert.Equal(slices.CountCombinations(len(test.values), test.k).Int64(), int64(len(test.out)))
+	}
+}
+
+// TestCombinationsWithReplacement verifies the creation of combinations
+// with replacement.
+func TestCombinationsWithReplacement(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []string
+		k      int
+		out    [][]string
+	}{
+		{
+			descr:  "Two out of three",
+			values: []string{"a", "b", "c"},
+			k:      2,
+			out:    [][]string{{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"}},
+		}, {
+			descr:  "More than available",
+			values: []string{"a", "b"},
+			k:      3,
+			out:    [][]string{{"a", "a", "a"}, {"a", "a", "b"}, {"a", "b", "b"}, {"b", "b", "b"}},
+		}, {
+			descr:  "Zero values",
+			values: []string{"a"},
+			k:      0,
+			out:    [][]string{{}},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			k:      2,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Collect(slices.CombinationsWithReplacement(test.values, test.k)), test.out)
+		assert.Equal(slices.CountCombinationsWithReplacement(len(test.values), test.k).Int64(), int64(len(test.out)))
+	}
+}
+
+// TestPermutations verifies the creation of permutations.
+func TestPermutations(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		out    [][]int
+	}{
+		{
+			descr:  "Three values",
+			values: []int{1, 2, 3},
+			out:    [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}},
+		}, {
+			descr:  "Duplicate values are permuted by position",
+			values: []int{1, 1},
+			out:    [][]int{{1, 1}, {1, 1}},
+		}, {
+			descr:  "Single value",
+			values: []int{1},
+			out:    [][]int{{1}},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    [][]int{{}},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Collect(slices.Permutations(test.values)), test.out)
+		assert.Equal(slices.CountPermutations(len(test.values)).Int64(), int64(len(test.out)))
+	}
+}
+
+// TestPowerSet verifies the creation of power sets.
+func TestPowerSet(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Collect(slices.PowerSet([]int{1, 2, 3})),
+		[][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}})
+	assert.Equal(slices.Collect(slices.PowerSet([]int{})), [][]int{{}})
+	assert.Equal(slices.CountPowerSet(3).Int64(), int64(8))
+}
+
+// TestLazyIteration verifies that huge spaces are not materialized.
+func TestLazyIteration(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := make([]int, 25)
+	for i := range values {
+		values[i] = i
+	}
+	it := slices.Permutations(values)
+	for i := 0; i < 3; i++ {
+		_, ok := it()
+		assert.True(ok)
+	}
+	last, ok := it()
+	assert.True(ok)
+	assert.Equal(last[22:], []int{23, 24, 22})
+	assert.Equal(slices.CountPermutations(25).String(), "15511210043330985984000000")
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkPermutations runs a performance test on iterating permutations.
+func BenchmarkPermutations(b *testing.B) {
+	it := slices.Permutations([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	for i := 0; i < b.N; i++ {
+		if _, ok := it(); !ok {
+			it = slices.Permutations([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		}
+	}
+}
+
+// EOF
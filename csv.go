@@ -0,0 +1,396 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// CSVColumn maps one CSV column to the values of a slice. Format returns
+// the text of the column for a value, Parse sets the matching part of a
+// value from the text.
+type CSVColumn[V any] struct {
+	Name   string
+	Format func(V) string
+	Parse  func(*V, string) error
+}
+
+// CSVOption configures the CSV functions.
+type CSVOption func(cfg *csvConfig)
+
+// CSVComma sets the field delimiter, the default is a comma.
+func CSVComma(comma rune) CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.comma = comma
+	}
+}
+
+// CSVNoHeader disables the header line. Columns are written and read
+// in the order of their definition.
+func CSVNoHeader() CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.header = false
+	}
+}
+
+// CSVFormatter sets the functions to format and parse fields of type T.
+// It is used when columns are created from struct tags and replaces the
+// standard conversion.
+func CSVFormatter[T any](format func(T) string, parse func(string) (T, error)) CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.formatters[reflect.TypeOf((*T)(nil)).Elem()] = csvFormatter{
+			format: func(rv reflect.Value) string {
+				return format(rv.Interface().(T))
+			},
+			parse: func(rv reflect.Value, s string) error {
+				t, err := parse(s)
+				if err != nil {
+					return err
+				}
+				rv.Set(reflect.ValueOf(&t).Elem())
+				return nil
+			},
+		}
+	}
+}
+
+//--------------------
+// COLUMNS
+//--------------------
+
+// CSVColumns creates the columns for a struct type. Each exported field is
+// a column named by its "csv" tag or by the field name if the tag is empty.
+// Fields tagged with "-" are skipped. Strings, booleans, numbers, types
+// registered with CSVFormatter(), and types implementing the encoding text
+// interfaces like time.Time are supported.
+func CSVColumns[V any](opts ...CSVOption) ([]CSVColumn[V], error) {
+	cfg := newCSVConfig(opts)
+	vt := reflect.TypeOf((*V)(nil)).Elem()
+	if vt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("slices: CSV columns need a struct type, got %v", vt)
+	}
+	columns := []CSVColumn[V]{}
+	for _, field := range reflect.VisibleFields(vt) {
+		if !field.IsExported() || field.Anonymous || viaPointer(vt, field.Index) {
+			continue
+		}
+		name := field.Tag.Get("csv")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		formatter, ok := cfg.formatterFor(field.Type)
+		if !ok {
+			return nil, fmt.Errorf("slices: unsupported CSV field type %v of %s", field.Type, field.Name)
+		}
+		index := field.Index
+		columns = append(columns, CSVColumn[V]{
+			Name: name,
+			Format: func(v V) string {
+				return formatter.format(reflect.ValueOf(v).FieldByIndex(index))
+			},
+			Parse: func(v *V, s string) error {
+				return formatter.parse(reflect.ValueOf(v).Elem().FieldByIndex(index), s)
+			},
+		})
+	}
+	return columns, nil
+}
+
+//--------------------
+// WRITING
+//--------------------
+
+// CSVWriter writes values one by one as CSV records, so the values never
+// need to be buffered. The header line is written before the first record.
+type CSVWriter[V any] struct {
+	w       *csv.Writer
+	columns []CSVColumn[V]
+	header  bool
+	record  []string
+}
+
+// NewCSVWriter creates a writer using the columns created by CSVColumns().
+func NewCSVWriter[V any](w io.Writer, opts ...CSVOption) (*CSVWriter[V], error) {
+	columns, err := CSVColumns[V](opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewCSVWriterWith(w, columns, opts...), nil
+}
+
+// NewCSVWriterWith creates a writer using the given columns.
+func NewCSVWriterWith[V any](w io.Writer, columns []CSVColumn[V], opts ...CSVOption) *CSVWriter[V] {
+	cfg := newCSVConfig(opts)
+	cw := &CSVWriter[V]{
+		w:       csv.NewWriter(w),
+		columns: columns,
+		header:  cfg.header,
+		record:  make([]string, len(columns)),
+	}
+	cw.w.Comma = cfg.comma
+	return cw
+}
+
+// Write writes one value as record.
+func (cw *CSVWriter[V]) Write(v V) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	for i, column := range cw.columns {
+		cw.record[i] = column.Format(v)
+	}
+	return cw.w.Write(cw.record)
+}
+
+// Flush writes all buffered data, including the header line if no
+// value has been written.
+func (cw *CSVWriter[V]) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// writeHeader writes the header line once if configured.
+func (cw *CSVWriter[V]) writeHeader() error {
+	if !cw.header {
+		return nil
+	}
+	cw.header = false
+	return cw.w.Write(Map(cw.columns, func(column CSVColumn[V]) string {
+		return column.Name
+	}))
+}
+
+// ToCSV writes the values as CSV using the columns created by CSVColumns().
+func ToCSV[V any](w io.Writer, ivs []V, opts ...CSVOption) error {
+	columns, err := CSVColumns[V](opts...)
+	if err != nil {
+		return err
+	}
+	return ToCSVWith(w, ivs, columns, opts...)
+}
+
+// ToCSVWith writes the values as CSV using the given columns.
+func ToCSVWith[V any](w io.Writer, ivs []V, columns []CSVColumn[V], opts ...CSVOption) error {
+	cw := NewCSVWriterWith(w, columns, opts...)
+	for _, v := range ivs {
+		if err := cw.Write(v); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+//--------------------
+// READING
+//--------------------
+
+// FromCSV reads values from CSV using the columns created by CSVColumns().
+func FromCSV[V any](r io.Reader, opts ...CSVOption) ([]V, error) {
+	columns, err := CSVColumns[V](opts...)
+	if err != nil {
+		return nil, err
+	}
+	return FromCSVWith(r, columns, opts...)
+}
+
+// FromCSVWith reads values from CSV using the given columns. With a header
+// line the columns are found by their names, so their order may differ and
+// unknown columns are ignored. A missing column returns an error.
+func FromCSVWith[V any](r io.Reader, columns []CSVColumn[V], opts ...CSVOption) ([]V, error) {
+	cfg := newCSVConfig(opts)
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.comma
+	cr.ReuseRecord = true
+	positions := make([]int, len(columns))
+	for i := range positions {
+		positions[i] = i
+	}
+	if cfg.header {
+		header, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return []V{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, column := range columns {
+			positions[i] = IndexOf(header, column.Name)
+			if positions[i] < 0 {
+				return nil, fmt.Errorf("slices: CSV column %q is missing", column.Name)
+			}
+		}
+	}
+	ovs := []V{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return ovs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v V
+		for i, column := range columns {
+			if positions[i] >= len(record) {
+				line, _ := cr.FieldPos(0)
+				return nil, fmt.Errorf("slices: CSV column %q is missing in line %d", column.Name, line)
+			}
+			if err := column.Parse(&v, record[positions[i]]); err != nil {
+				line, _ := cr.FieldPos(positions[i])
+				return nil, fmt.Errorf("slices: CSV column %q in line %d: %w", column.Name, line, err)
+			}
+		}
+		ovs = append(ovs, v)
+	}
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// viaPointer returns true if the field is promoted by an embedded pointer.
+func viaPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// csvConfig contains the configuration of the CSV functions.
+type csvConfig struct {
+	comma      rune
+	header     bool
+	formatters map[reflect.Type]csvFormatter
+}
+
+// newCSVConfig creates the configuration out of the options.
+func newCSVConfig(opts []CSVOption) *csvConfig {
+	cfg := &csvConfig{
+		comma:      ',',
+		header:     true,
+		formatters: map[reflect.Type]csvFormatter{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// csvFormatter formats and parses the reflected fields of one type.
+type csvFormatter struct {
+	format func(rv reflect.Value) string
+	parse  func(rv reflect.Value, s string) error
+}
+
+// formatterFor returns the formatter for the type. Registered formatters
+// are preferred, then the encoding text interfaces, then the kind.
+func (cfg *csvConfig) formatterFor(t reflect.Type) (csvFormatter, bool) {
+	if formatter, ok := cfg.formatters[t]; ok {
+		return formatter, true
+	}
+	if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				// Values which cannot be marshalled are written as empty
+				// text and fail when parsed again.
+				text, _ := rv.Interface().(encoding.TextMarshaler).MarshalText()
+				return string(text)
+			},
+			parse: func(rv reflect.Value, s string) error {
+				return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			},
+		}, true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				return rv.String()
+			},
+			parse: func(rv reflect.Value, s string) error {
+				rv.SetString(s)
+				return nil
+			},
+		}, true
+	case reflect.Bool:
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				return strconv.FormatBool(rv.Bool())
+			},
+			parse: func(rv reflect.Value, s string) error {
+				b, err := strconv.ParseBool(s)
+				rv.SetBool(b)
+				return err
+			},
+		}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				return strconv.FormatInt(rv.Int(), 10)
+			},
+			parse: func(rv reflect.Value, s string) error {
+				i, err := strconv.ParseInt(s, 10, t.Bits())
+				rv.SetInt(i)
+				return err
+			},
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				return strconv.FormatUint(rv.Uint(), 10)
+			},
+			parse: func(rv reflect.Value, s string) error {
+				u, err := strconv.ParseUint(s, 10, t.Bits())
+				rv.SetUint(u)
+				return err
+			},
+		}, true
+	case reflect.Float32, reflect.Float64:
+		return csvFormatter{
+			format: func(rv reflect.Value) string {
+				return strconv.FormatFloat(rv.Float(), 'g', -1, t.Bits())
+			},
+			parse: func(rv reflect.Value, s string) error {
+				f, err := strconv.ParseFloat(s, t.Bits())
+				rv.SetFloat(f)
+				return err
+			},
+		}, true
+	}
+	return csvFormatter{}, false
+}
+
+// EOF
@@ -0,0 +1,229 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// Cents is a custom type for testing CSV formatters.
+type Cents int64
+
+// Record is a record type for testing CSV.
+type Record struct {
+	ID      uint16    `csv:"id"`
+	Name    string    `csv:"name"`
+	Active  bool      `csv:"active"`
+	Score   float64   `csv:"score"`
+	Price   Cents     `csv:"price"`
+	Created time.Time `csv:"created"`
+	Note    string
+	Secret  string `csv:"-"`
+	hidden  int
+}
+
+var (
+	day     = time.Date(2023, 5, 17, 12, 30, 0, 0, time.UTC)
+	records = []Record{
+		{3, "carol", true, 0.25, 1999, day, "c", "", 0},
+		{1, "alice, \"the first\"", false, -1e-7, 5, day.Add(time.Hour), "", "", 0},
+		{2, "bob", true, 3, 120000, day.Add(-time.Hour), "b\nb", "", 0},
+	}
+	formatCents = slices.CSVFormatter(
+		func(c Cents) string { return strconv.FormatFloat(float64(c)/100, 'f', 2, 64) },
+		func(s string) (Cents, error) {
+			f, err := strconv.ParseFloat(s, 64)
+			return Cents(f*100 + 0.5), err
+		},
+	)
+)
+
+// TestCSVColumns verifies the creation of columns from struct tags.
+func TestCSVColumns(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	columns, err := slices.CSVColumns[Record]()
+	assert.NoError(err)
+	assert.Equal(slices.Map(columns, func(c slices.CSVColumn[Record]) string { return c.Name }),
+		[]string{"id", "name", "active", "score", "price", "created", "Note"})
+	assert.Equal(columns[3].Format(records[1]), "-1e-07")
+	assert.Equal(columns[5].Format(records[0]), "2023-05-17T12:30:00Z")
+
+	var r Record
+	assert.NoError(columns[2].Parse(&r, "true"))
+	assert.True(r.Active)
+	assert.ErrorMatch(columns[0].Parse(&r, "70000"), ".*out of range.*")
+
+	_, err = slices.CSVColumns[int]()
+	assert.ErrorMatch(err, ".*need a struct type.*")
+	_, err = slices.CSVColumns[struct{ C chan int }]()
+	assert.ErrorMatch(err, ".*unsupported CSV field type.*")
+}
+
+// TestToCSV verifies the writing of CSV.
+func TestToCSV(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	var buf bytes.Buffer
+	err := slices.ToCSV(&buf, records[:1], formatCents)
+	assert.NoError(err)
+	assert.Equal(buf.String(), "id,name,active,score,price,created,Note\n"+
+		"3,carol,true,0.25,19.99,2023-05-17T12:30:00Z,c\n")
+
+	buf.Reset()
+	err = slices.ToCSV(&buf, []Record{}, slices.CSVComma(';'))
+	assert.NoError(err)
+	assert.Equal(buf.String(), "id;name;active;score;price;created;Note\n")
+
+	buf.Reset()
+	err = slices.ToCSV[Record](&buf, nil, slices.CSVNoHeader())
+	assert.NoError(err)
+	assert.Equal(buf.String(), "")
+}
+
+// TestCSVWriter verifies the streaming writing of CSV with columns.
+func TestCSVWriter(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	columns := []slices.CSVColumn[slices.Pair[string, int]]{
+		{
+			Name:   "word",
+			Format: func(p slices.Pair[string, int]) string { return p.First },
+		}, {
+			Name:   "length",
+			Format: func(p slices.Pair[string, int]) string { return strconv.Itoa(p.Second) },
+		},
+	}
+	var buf bytes.Buffer
+	cw := slices.NewCSVWriterWith(&buf, columns, slices.CSVComma('\t'))
+
+	for _, word := range []string{"a", "bb"} {
+		assert.NoError(cw.Write(slices.Pair[string, int]{word, len(word)}))
+	}
+	assert.Equal(buf.String(), "")
+	assert.NoError(cw.Flush())
+	assert.Equal(buf.String(), "word\tlength\na\t1\nbb\t2\n")
+
+	_, err := slices.NewCSVWriter[string](&buf)
+	assert.ErrorMatch(err, ".*need a struct type.*")
+}
+
+// TestFromCSV verifies the reading of CSV.
+func TestFromCSV(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	type Entry struct {
+		Name  string `csv:"name"`
+		Count int    `csv:"count"`
+	}
+	tests := []struct {
+		descr string
+		csv   string
+		opts  []slices.CSVOption
+		out   []Entry
+		err   string
+	}{
+		{
+			descr: "Header in column order",
+			csv:   "name,count\na,1\nb,2\n",
+			out:   []Entry{{"a", 1}, {"b", 2}},
+		}, {
+			descr: "Header in other order with unknown column",
+			csv:   "extra,count,name\nx,1,a\n",
+			out:   []Entry{{"a", 1}},
+		}, {
+			descr: "No header",
+			csv:   "a;1\nb;2\n",
+			opts:  []slices.CSVOption{slices.CSVNoHeader(), slices.CSVComma(';')},
+			out:   []Entry{{"a", 1}, {"b", 2}},
+		}, {
+			descr: "Only header",
+			csv:   "name,count\n",
+			out:   []Entry{},
+		}, {
+			descr: "Empty input",
+			csv:   "",
+			out:   []Entry{},
+		}, {
+			descr: "Missing column",
+			csv:   "name\na\n",
+			err:   `.*CSV column "count" is missing.*`,
+		}, {
+			descr: "Missing field",
+			csv:   "a\n",
+			opts:  []slices.CSVOption{slices.CSVNoHeader()},
+			err:   `.*CSV column "count" is missing in line 1.*`,
+		}, {
+			descr: "Invalid value",
+			csv:   "name,count\na,1\nb,two\n",
+			err:   `.*CSV column "count" in line 3.*invalid syntax.*`,
+		}, {
+			descr: "Invalid CSV",
+			csv:   "name,count\na,1,2\n",
+			err:   `.*wrong number of fields.*`,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		out, err := slices.FromCSV[Entry](strings.NewReader(test.csv), test.opts...)
+		if test.err != "" {
+			assert.ErrorMatch(err, test.err)
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(out, test.out)
+	}
+}
+
+// TestCSVRoundTrip verifies that written values are read again unchanged,
+// also after sorting and grouping.
+func TestCSVRoundTrip(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	sorted := slices.SortWith(records, func(rs []Record, i, j int) bool {
+		return rs[i].ID < rs[j].ID
+	})
+	for _, opts := range [][]slices.CSVOption{
+		{formatCents},
+		{slices.CSVNoHeader(), slices.CSVComma('|')},
+	} {
+		var buf bytes.Buffer
+		assert.NoError(slices.ToCSV(&buf, sorted, opts...))
+		out, err := slices.FromCSV[Record](&buf, opts...)
+		assert.NoError(err)
+		assert.Equal(out, sorted)
+	}
+
+	active := slices.GroupBy(slices.NewQuery(records), func(r Record) bool { return r.Active })
+	totals := slices.Aggregate(active, func(rs []Record) int { return len(rs) }).ToSlice()
+	var buf bytes.Buffer
+	assert.NoError(slices.ToCSV(&buf, totals))
+	assert.Equal(buf.String(), "First,Second\ntrue,2\nfalse,1\n")
+	out, err := slices.FromCSV[slices.Pair[bool, int]](&buf)
+	assert.NoError(err)
+	assert.Equal(out, totals)
+}
+
+// EOF
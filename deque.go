@@ -0,0 +1,148 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// CONSTANTS
+//--------------------
+
+// dequeMinCapacity is the initial capacity of a deque.
+const dequeMinCapacity = 16
+
+//--------------------
+// DEQUE
+//--------------------
+
+// Deque is a double-ended queue growing with its values. Pushing and
+// popping at both ends take amortized constant time. The zero value is
+// an empty deque ready to use.
+type Deque[V any] struct {
+	values []V
+	start  int
+	length int
+}
+
+// NewDeque creates an empty deque.
+func NewDeque[V any]() *Deque[V] {
+	return &Deque[V]{}
+}
+
+// DequeFromSlice creates a deque containing the values of the slice
+// from front to back.
+func DequeFromSlice[V any](ivs []V) *Deque[V] {
+	capacity := dequeMinCapacity
+	for capacity < len(ivs) {
+		capacity *= 2
+	}
+	values := make([]V, capacity)
+	copy(values, ivs)
+	return &Deque[V]{
+		values: values,
+		length: len(ivs),
+	}
+}
+
+// Len returns the number of values.
+func (d *Deque[V]) Len() int {
+	return d.length
+}
+
+// PeekBack returns the value at the back without removing it. An empty
+// deque returns the default value and false.
+func (d *Deque[V]) PeekBack() (V, bool) {
+	if d.length == 0 {
+		var v V
+		return v, false
+	}
+	return d.values[d.index(d.length-1)], true
+}
+
+// PeekFront returns the value at the front without removing it. An empty
+// deque returns the default value and false.
+func (d *Deque[V]) PeekFront() (V, bool) {
+	if d.length == 0 {
+		var v V
+		return v, false
+	}
+	return d.values[d.start], true
+}
+
+// PopBack removes and returns the value at the back. An empty deque
+// returns the default value and false.
+func (d *Deque[V]) PopBack() (V, bool) {
+	v, ok := d.PeekBack()
+	if ok {
+		var zero V
+		d.values[d.index(d.length-1)] = zero
+		d.length--
+	}
+	return v, ok
+}
+
+// PopFront removes and returns the value at the front. An empty deque
+// returns the default value and false.
+func (d *Deque[V]) PopFront() (V, bool) {
+	v, ok := d.PeekFront()
+	if ok {
+		var zero V
+		d.values[d.start] = zero
+		d.start = d.index(1)
+		d.length--
+	}
+	return v, ok
+}
+
+// PushBack adds a value at the back.
+func (d *Deque[V]) PushBack(v V) {
+	d.grow()
+	d.values[d.index(d.length)] = v
+	d.length++
+}
+
+// PushFront adds a value at the front.
+func (d *Deque[V]) PushFront(v V) {
+	d.grow()
+	d.start = d.index(len(d.values) - 1)
+	d.values[d.start] = v
+	d.length++
+}
+
+// ToSlice returns the values from front to back as a new slice.
+func (d *Deque[V]) ToSlice() []V {
+	ovs := make([]V, d.length)
+	for i := range ovs {
+		ovs[i] = d.values[d.index(i)]
+	}
+	return ovs
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// index returns the position of the i-th value in the buffer.
+func (d *Deque[V]) index(i int) int {
+	return (d.start + i) % len(d.values)
+}
+
+// grow doubles the buffer if it is full.
+func (d *Deque[V]) grow() {
+	if d.length < len(d.values) {
+		return
+	}
+	capacity := dequeMinCapacity
+	if len(d.values) > 0 {
+		capacity = len(d.values) * 2
+	}
+	values := make([]V, capacity)
+	copy(values, d.ToSlice())
+	d.values = values
+	d.start = 0
+}
+
+// EOF
@@ -0,0 +1,117 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestDequePushPop verifies pushing and popping at both ends.
+func TestDequePushPop(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	d := slices.NewDeque[int]()
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	assert.Equal(d.Len(), 4)
+	assert.Equal(d.ToSlice(), []int{0, 1, 2, 3})
+
+	v, ok := d.PeekFront()
+	assert.True(ok)
+	assert.Equal(v, 0)
+	v, ok = d.PeekBack()
+	assert.True(ok)
+	assert.Equal(v, 3)
+
+	v, ok = d.PopFront()
+	assert.True(ok)
+	assert.Equal(v, 0)
+	v, ok = d.PopBack()
+	assert.True(ok)
+	assert.Equal(v, 3)
+	assert.Equal(d.ToSlice(), []int{1, 2})
+
+	d.PopBack()
+	d.PopBack()
+	_, ok = d.PopFront()
+	assert.False(ok)
+	_, ok = d.PopBack()
+	assert.False(ok)
+	assert.Equal(d.ToSlice(), []int{})
+}
+
+// TestDequeGrowing verifies the growing with many values.
+func TestDequeGrowing(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	var d slices.Deque[int]
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	assert.Equal(d.Len(), 100)
+	vs := d.ToSlice()
+	assert.Equal(vs[0], 99)
+	assert.Equal(vs[49], 1)
+	assert.Equal(vs[50], 0)
+	assert.Equal(vs[99], 98)
+
+	// Use as queue.
+	for i := 0; i < 100; i++ {
+		d.PopFront()
+		d.PushBack(i)
+	}
+	assert.Equal(d.ToSlice(), slices.Seq(0, 99, 1))
+}
+
+// TestDequeFromSlice verifies the creation out of slices.
+func TestDequeFromSlice(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := slices.Seq(1, 40, 1)
+	d := slices.DequeFromSlice(values)
+	assert.Equal(d.ToSlice(), values)
+
+	d.PushFront(0)
+	d.PushBack(41)
+	assert.Equal(d.ToSlice(), slices.Seq(0, 41, 1))
+
+	assert.Equal(slices.DequeFromSlice[int](nil).Len(), 0)
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkDequeQueue runs a performance test on using a deque as queue.
+func BenchmarkDequeQueue(b *testing.B) {
+	d := slices.DequeFromSlice(slices.Seq(1, 1000, 1))
+	for i := 0; i < b.N; i++ {
+		d.PopFront()
+		d.PushBack(i)
+	}
+}
+
+// EOF
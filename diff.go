@@ -0,0 +1,197 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"errors"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// ErrInvalidEditScript is returned if an edit script does not fit to
+// the slice it shall be applied to.
+var ErrInvalidEditScript = errors.New("slices: invalid edit script")
+
+// EditKind describes the kind of an edit operation.
+type EditKind int
+
+// Kinds of edit operations.
+const (
+	EditKeep EditKind = iota
+	EditDelete
+	EditInsert
+)
+
+// String implements fmt.Stringer.
+func (k EditKind) String() string {
+	switch k {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return "unknown"
+}
+
+// Edit is one operation of an edit script transforming a slice A
+// into a slice B. APos and BPos are the positions in both slices
+// at the time of the operation. Value is the kept, deleted, or
+// inserted value.
+type Edit[V any] struct {
+	Kind  EditKind
+	APos  int
+	BPos  int
+	Value V
+}
+
+//--------------------
+// DIFF
+//--------------------
+
+// Diff returns the shortest edit script transforming the slice a into
+// the slice b. It's computed with the Myers difference algorithm.
+func Diff[V comparable](a, b []V) []Edit[V] {
+	return DiffWith(a, b, func(va, vb V) bool {
+		return va == vb
+	})
+}
+
+// DiffWith returns the shortest edit script like Diff() but uses the
+// function equal() for the comparison of values.
+func DiffWith[V any](a, b []V, equal func(V, V) bool) []Edit[V] {
+	if a == nil && b == nil {
+		return nil
+	}
+	return myers(a, b, equal)
+}
+
+// LongestCommonSubsequence returns the longest sequence of values
+// contained in both slices in the same order, but not necessarily
+// contiguous.
+func LongestCommonSubsequence[V comparable](a, b []V) []V {
+	edits := Diff(a, b)
+	if edits == nil {
+		return nil
+	}
+	lcs := []V{}
+	for _, edit := range edits {
+		if edit.Kind == EditKeep {
+			lcs = append(lcs, edit.Value)
+		}
+	}
+	return lcs
+}
+
+// Patch applies the edit script to the slice and returns the result as
+// a new slice. The positions of kept and deleted values have to match
+// the slice, otherwise ErrInvalidEditScript is returned.
+func Patch[V any](ivs []V, edits []Edit[V]) ([]V, error) {
+	if ivs == nil && edits == nil {
+		return nil, nil
+	}
+	ovs := []V{}
+	apos := 0
+	for _, edit := range edits {
+		switch edit.Kind {
+		case EditKeep, EditDelete:
+			if edit.APos != apos || apos >= len(ivs) {
+				return nil, ErrInvalidEditScript
+			}
+			if edit.Kind == EditKeep {
+				ovs = append(ovs, ivs[apos])
+			}
+			apos++
+		case EditInsert:
+			ovs = append(ovs, edit.Value)
+		default:
+			return nil, ErrInvalidEditScript
+		}
+	}
+	if apos != len(ivs) {
+		return nil, ErrInvalidEditScript
+	}
+	return ovs, nil
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// myers computes the shortest edit script with the Myers algorithm. The
+// furthest reaching paths of each step are traced for the backtracking.
+func myers[V any](a, b []V, equal func(V, V) bool) []Edit[V] {
+	n, m := len(a), len(b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	trace := [][]int{}
+	// Find the shortest path.
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, Copy(v))
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && equal(a[x], b[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+	// Backtrack the path.
+	edits := []Edit[V]{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := v[offset+pk]
+		py := px - pk
+		for x > px && y > py {
+			x--
+			y--
+			edits = append(edits, Edit[V]{EditKeep, x, y, a[x]})
+		}
+		if d > 0 {
+			if x == px {
+				edits = append(edits, Edit[V]{EditInsert, px, py, b[py]})
+			} else {
+				edits = append(edits, Edit[V]{EditDelete, px, py, a[px]})
+			}
+		}
+		x, y = px, py
+	}
+	return Reverse(edits)
+}
+
+// EOF
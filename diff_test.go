@@ -0,0 +1,176 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strings"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestDiff verifies the computation of edit scripts.
+func TestDiff(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr   string
+		a       []string
+		b       []string
+		changes int
+	}{
+		{
+			descr:   "Equal slices",
+			a:       []string{"a", "b", "c"},
+			b:       []string{"a", "b", "c"},
+			changes: 0,
+		}, {
+			descr:   "Classic Myers example",
+			a:       strings.Split("ABCABBA", ""),
+			b:       strings.Split("CBABAC", ""),
+			changes: 5,
+		}, {
+			descr:   "Insert only",
+			a:       []string{"a", "c"},
+			b:       []string{"a", "b", "c", "d"},
+			changes: 2,
+		}, {
+			descr:   "Delete only",
+			a:       []string{"a", "b", "c", "d"},
+			b:       []string{"b", "d"},
+			changes: 2,
+		}, {
+			descr:   "Completely different",
+			a:       []string{"a", "b"},
+			b:       []string{"c", "d", "e"},
+			changes: 5,
+		}, {
+			descr:   "Empty first slice",
+			a:       []string{},
+			b:       []string{"a", "b"},
+			changes: 2,
+		}, {
+			descr:   "Nil second slice",
+			a:       []string{"a", "b"},
+			b:       nil,
+			changes: 2,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		edits := slices.Diff(test.a, test.b)
+		changes := slices.Filter(edits, func(e slices.Edit[string]) bool {
+			return e.Kind != slices.EditKeep
+		})
+		assert.Length(changes, test.changes)
+		patched, err := slices.Patch(test.a, edits)
+		assert.NoError(err)
+		assert.Equal(slices.Append(patched), slices.Append(test.b))
+	}
+
+	assert.Nil(slices.Diff[int](nil, nil))
+}
+
+// TestDiffEdits verifies the individual edit operations.
+func TestDiffEdits(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	edits := slices.Diff([]int{1, 2, 3}, []int{1, 4, 3})
+	assert.Equal(edits, []slices.Edit[int]{
+		{Kind: slices.EditKeep, APos: 0, BPos: 0, Value: 1},
+		{Kind: slices.EditDelete, APos: 1, BPos: 1, Value: 2},
+		{Kind: slices.EditInsert, APos: 2, BPos: 1, Value: 4},
+		{Kind: slices.EditKeep, APos: 2, BPos: 2, Value: 3},
+	})
+	assert.Equal(slices.EditInsert.String(), "insert")
+}
+
+// TestDiffWith verifies the computation of edit scripts with an
+// equality function.
+func TestDiffWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	equal := func(a, b string) bool { return strings.EqualFold(a, b) }
+	a := []string{"Alpha", "Beta", "Gamma"}
+	b := []string{"alpha", "gamma", "delta"}
+
+	edits := slices.DiffWith(a, b, equal)
+	kinds := slices.Map(edits, func(e slices.Edit[string]) slices.EditKind { return e.Kind })
+	assert.Equal(kinds, []slices.EditKind{
+		slices.EditKeep, slices.EditDelete, slices.EditKeep, slices.EditInsert,
+	})
+	patched, err := slices.Patch(a, edits)
+	assert.NoError(err)
+	assert.Equal(patched, []string{"Alpha", "Gamma", "delta"})
+}
+
+// TestLongestCommonSubsequence verifies the finding of the longest
+// common subsequence.
+func TestLongestCommonSubsequence(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr string
+		a     []int
+		b     []int
+		out   []int
+	}{
+		{
+			descr: "Interleaved values",
+			a:     []int{1, 2, 3, 4, 5},
+			b:     []int{2, 7, 4, 8, 5},
+			out:   []int{2, 4, 5},
+		}, {
+			descr: "No common values",
+			a:     []int{1, 2},
+			b:     []int{3, 4},
+			out:   []int{},
+		}, {
+			descr: "Nil slices",
+			a:     nil,
+			b:     nil,
+			out:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.LongestCommonSubsequence(test.a, test.b), test.out)
+	}
+}
+
+// TestPatch verifies the rejection of invalid edit scripts.
+func TestPatch(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	edits := slices.Diff([]int{1, 2, 3}, []int{2, 3, 4})
+
+	_, err := slices.Patch([]int{1, 2}, edits)
+	assert.ErrorMatch(err, ".*invalid edit script.*")
+	_, err = slices.Patch([]int{1, 2, 3, 4}, edits)
+	assert.ErrorMatch(err, ".*invalid edit script.*")
+	_, err = slices.Patch([]int{1, 2, 3}, []slices.Edit[int]{{Kind: slices.EditKeep, APos: 1}})
+	assert.ErrorMatch(err, ".*invalid edit script.*")
+
+	patched, err := slices.Patch[int](nil, nil)
+	assert.NoError(err)
+	assert.Nil(patched)
+}
+
+// EOF
@@ -0,0 +1,273 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// DISTANCE
+//--------------------
+
+// DamerauLevenshtein returns the number of deletions, insertions,
+// substitutions, and transpositions of neighboring values needed to
+// transform slice a into slice b. It's computed as optimal string alignment
+// distance, so no subslice is edited more than once.
+func DamerauLevenshtein[V comparable](a, b []V) int {
+	d, _ := damerauLevenshtein(a, b, isEqual[V], -1)
+	return d
+}
+
+// DamerauLevenshteinBounded works like DamerauLevenshtein() but gives up
+// as soon as the distance exceeds the limit. In this case limit+1 and false
+// are returned.
+func DamerauLevenshteinBounded[V comparable](a, b []V, limit int) (int, bool) {
+	return damerauLevenshtein(a, b, isEqual[V], limit)
+}
+
+// DamerauLevenshteinWith works like DamerauLevenshtein() but uses the
+// function equal() for the comparison of values.
+func DamerauLevenshteinWith[V any](a, b []V, equal func(V, V) bool) int {
+	d, _ := damerauLevenshtein(a, b, equal, -1)
+	return d
+}
+
+// Hamming returns the number of positions with different values in the two
+// slices. Both have to be of equal length, otherwise ErrLengthMismatch is
+// returned.
+func Hamming[V comparable](a, b []V) (int, error) {
+	d, _, err := hamming(a, b, isEqual[V], -1)
+	return d, err
+}
+
+// HammingBounded works like Hamming() but gives up as soon as the distance
+// exceeds the limit. In this case limit+1 and false are returned.
+func HammingBounded[V comparable](a, b []V, limit int) (int, bool, error) {
+	return hamming(a, b, isEqual[V], limit)
+}
+
+// HammingWith works like Hamming() but uses the function equal() for the
+// comparison of values.
+func HammingWith[V any](a, b []V, equal func(V, V) bool) (int, error) {
+	d, _, err := hamming(a, b, equal, -1)
+	return d, err
+}
+
+// Jaccard returns the Jaccard distance of the values of both slices taken
+// as sets. It's one minus the size of the intersection divided by the size
+// of the union, so 0.0 means equal sets and 1.0 disjoint ones. Two empty
+// slices have a distance of 0.0.
+func Jaccard[V comparable](a, b []V) float64 {
+	d, _ := JaccardBounded(a, b, 1.0)
+	return d
+}
+
+// JaccardBounded works like Jaccard() but returns false if the distance
+// exceeds the limit. It gives up early if the lower bound derived from the
+// set sizes alone already exceeds the limit. In this case this bound is
+// returned.
+func JaccardBounded[V comparable](a, b []V, limit float64) (float64, bool) {
+	as := Unique(a)
+	bs := Unique(b)
+	if d := jaccardLowerBound(len(as), len(bs)); d > limit {
+		return d, false
+	}
+	contained := make(map[V]struct{}, len(as))
+	for _, v := range as {
+		contained[v] = struct{}{}
+	}
+	intersection := 0
+	for _, v := range bs {
+		if _, ok := contained[v]; ok {
+			intersection++
+		}
+	}
+	d := jaccardDistance(intersection, len(as)+len(bs)-intersection)
+	return d, d <= limit
+}
+
+// JaccardWith works like Jaccard() but uses the function equal() for the
+// comparison of values.
+func JaccardWith[V any](a, b []V, equal func(V, V) bool) float64 {
+	as := uniqueWithEqual(a, equal)
+	bs := uniqueWithEqual(b, equal)
+	intersection := 0
+	for _, bv := range bs {
+		if ContainsAny(as, func(av V) bool { return equal(av, bv) }) {
+			intersection++
+		}
+	}
+	return jaccardDistance(intersection, len(as)+len(bs)-intersection)
+}
+
+// Levenshtein returns the number of deletions, insertions, and substitutions
+// needed to transform slice a into slice b.
+func Levenshtein[V comparable](a, b []V) int {
+	d, _ := levenshtein(a, b, isEqual[V], -1)
+	return d
+}
+
+// LevenshteinBounded works like Levenshtein() but gives up as soon as the
+// distance exceeds the limit. In this case limit+1 and false are returned.
+func LevenshteinBounded[V comparable](a, b []V, limit int) (int, bool) {
+	return levenshtein(a, b, isEqual[V], limit)
+}
+
+// LevenshteinWith works like Levenshtein() but uses the function equal()
+// for the comparison of values.
+func LevenshteinWith[V any](a, b []V, equal func(V, V) bool) int {
+	d, _ := levenshtein(a, b, equal, -1)
+	return d
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// isEqual compares two comparable values.
+func isEqual[V comparable](a, b V) bool {
+	return a == b
+}
+
+// minOf returns the smallest of the passed integers.
+func minOf(i int, is ...int) int {
+	for _, j := range is {
+		if j < i {
+			i = j
+		}
+	}
+	return i
+}
+
+// levenshtein computes the Levenshtein distance row by row. A negative
+// limit means unbounded.
+func levenshtein[V any](a, b []V, equal func(V, V) bool, limit int) (int, bool) {
+	if limit >= 0 && abs(len(a)-len(b)) > limit {
+		return limit + 1, false
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if equal(a[i-1], b[j-1]) {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = minOf(rowMin, curr[j])
+		}
+		if limit >= 0 && rowMin > limit {
+			return limit + 1, false
+		}
+		prev, curr = curr, prev
+	}
+	d := prev[len(b)]
+	if limit >= 0 && d > limit {
+		return limit + 1, false
+	}
+	return d, true
+}
+
+// damerauLevenshtein computes the optimal string alignment distance row
+// by row. A negative limit means unbounded.
+func damerauLevenshtein[V any](a, b []V, equal func(V, V) bool, limit int) (int, bool) {
+	if limit >= 0 && abs(len(a)-len(b)) > limit {
+		return limit + 1, false
+	}
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if equal(a[i-1], b[j-1]) {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && equal(a[i-1], b[j-2]) && equal(a[i-2], b[j-1]) {
+				curr[j] = minOf(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = minOf(rowMin, curr[j])
+		}
+		// A transposition may lower the next row only by reaching
+		// back two rows, so both have to exceed the limit.
+		if limit >= 0 && rowMin > limit && minOf(prev[0], prev[1:]...) > limit {
+			return limit + 1, false
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	d := prev[len(b)]
+	if limit >= 0 && d > limit {
+		return limit + 1, false
+	}
+	return d, true
+}
+
+// hamming computes the Hamming distance. A negative limit means unbounded.
+func hamming[V any](a, b []V, equal func(V, V) bool, limit int) (int, bool, error) {
+	if len(a) != len(b) {
+		return 0, false, ErrLengthMismatch
+	}
+	d := 0
+	for i := range a {
+		if !equal(a[i], b[i]) {
+			d++
+			if limit >= 0 && d > limit {
+				return limit + 1, false, nil
+			}
+		}
+	}
+	return d, true, nil
+}
+
+// uniqueWithEqual returns the values of the slice without duplicates
+// determined by the function equal().
+func uniqueWithEqual[V any](ivs []V, equal func(V, V) bool) []V {
+	ovs := []V{}
+	for _, iv := range ivs {
+		if !ContainsAny(ovs, func(ov V) bool { return equal(ov, iv) }) {
+			ovs = append(ovs, iv)
+		}
+	}
+	return ovs
+}
+
+// jaccardDistance computes the Jaccard distance out of the sizes
+// of intersection and union.
+func jaccardDistance(intersection, union int) float64 {
+	if union == 0 {
+		return 0.0
+	}
+	return 1.0 - float64(intersection)/float64(union)
+}
+
+// jaccardLowerBound returns the smallest possible Jaccard distance of
+// two sets with the given sizes.
+func jaccardLowerBound(la, lb int) float64 {
+	if la > lb {
+		la, lb = lb, la
+	}
+	return jaccardDistance(la, lb)
+}
+
+// abs returns the absolute value of an integer.
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// EOF
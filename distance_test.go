@@ -0,0 +1,220 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strings"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestLevenshtein verifies the computation of the Levenshtein distance.
+func TestLevenshtein(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr string
+		a     string
+		b     string
+		out   int
+	}{
+		{
+			descr: "Equal slices",
+			a:     "kitten",
+			b:     "kitten",
+			out:   0,
+		}, {
+			descr: "Classic example",
+			a:     "kitten",
+			b:     "sitting",
+			out:   3,
+		}, {
+			descr: "Transposition",
+			a:     "ca",
+			b:     "ac",
+			out:   2,
+		}, {
+			descr: "Empty first slice",
+			a:     "",
+			b:     "abc",
+			out:   3,
+		}, {
+			descr: "Empty slices",
+			a:     "",
+			b:     "",
+			out:   0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		a := strings.Split(test.a, "")
+		b := strings.Split(test.b, "")
+		assert.Equal(slices.Levenshtein(a, b), test.out)
+		assert.Equal(slices.LevenshteinWith(a, b, func(x, y string) bool { return x == y }), test.out)
+		d, ok := slices.LevenshteinBounded(a, b, test.out)
+		assert.True(ok)
+		assert.Equal(d, test.out)
+		if test.out > 0 {
+			d, ok = slices.LevenshteinBounded(a, b, test.out-1)
+			assert.False(ok)
+			assert.Equal(d, test.out)
+		}
+	}
+}
+
+// TestDamerauLevenshtein verifies the computation of the Damerau-Levenshtein
+// distance.
+func TestDamerauLevenshtein(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr string
+		a     string
+		b     string
+		out   int
+	}{
+		{
+			descr: "Classic example",
+			a:     "kitten",
+			b:     "sitting",
+			out:   3,
+		}, {
+			descr: "Transposition",
+			a:     "ca",
+			b:     "ac",
+			out:   1,
+		}, {
+			descr: "Optimal string alignment",
+			a:     "ca",
+			b:     "abc",
+			out:   3,
+		}, {
+			descr: "Multiple transpositions",
+			a:     "abcdef",
+			b:     "badcfe",
+			out:   3,
+		}, {
+			descr: "Empty slices",
+			a:     "",
+			b:     "",
+			out:   0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		a := strings.Split(test.a, "")
+		b := strings.Split(test.b, "")
+		assert.Equal(slices.DamerauLevenshtein(a, b), test.out)
+		assert.Equal(slices.DamerauLevenshteinWith(a, b, func(x, y string) bool { return x == y }), test.out)
+		d, ok := slices.DamerauLevenshteinBounded(a, b, test.out)
+		assert.True(ok)
+		assert.Equal(d, test.out)
+		if test.out > 0 {
+			d, ok = slices.DamerauLevenshteinBounded(a, b, test.out-1)
+			assert.False(ok)
+			assert.Equal(d, test.out)
+		}
+	}
+}
+
+// TestHamming verifies the computation of the Hamming distance.
+func TestHamming(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	d, err := slices.Hamming([]int{1, 0, 1, 1, 1, 0, 1}, []int{1, 0, 0, 1, 0, 0, 1})
+	assert.NoError(err)
+	assert.Equal(d, 2)
+
+	d, err = slices.HammingWith([]string{"a", "B"}, []string{"A", "b"}, strings.EqualFold)
+	assert.NoError(err)
+	assert.Equal(d, 0)
+
+	d, ok, err := slices.HammingBounded([]int{1, 2, 3, 4}, []int{4, 3, 2, 1}, 2)
+	assert.NoError(err)
+	assert.False(ok)
+	assert.Equal(d, 3)
+
+	d, ok, err = slices.HammingBounded([]int{1, 2, 3, 4}, []int{1, 2, 3, 5}, 2)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(d, 1)
+
+	_, err = slices.Hamming([]int{1, 2}, []int{1})
+	assert.ErrorMatch(err, ".*length mismatch.*")
+
+	d, err = slices.Hamming[int](nil, nil)
+	assert.NoError(err)
+	assert.Equal(d, 0)
+}
+
+// TestJaccard verifies the computation of the Jaccard distance.
+func TestJaccard(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr string
+		a     []int
+		b     []int
+		out   float64
+	}{
+		{
+			descr: "Equal sets",
+			a:     []int{1, 2, 3},
+			b:     []int{3, 2, 1, 1},
+			out:   0.0,
+		}, {
+			descr: "Overlapping sets",
+			a:     []int{1, 2, 3},
+			b:     []int{2, 3, 4},
+			out:   0.5,
+		}, {
+			descr: "Disjoint sets",
+			a:     []int{1, 2},
+			b:     []int{3, 4},
+			out:   1.0,
+		}, {
+			descr: "Nil slices",
+			a:     nil,
+			b:     nil,
+			out:   0.0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.About(slices.Jaccard(test.a, test.b), test.out, 0.0001)
+		assert.About(slices.JaccardWith(test.a, test.b, func(x, y int) bool { return x == y }), test.out, 0.0001)
+	}
+
+	d, ok := slices.JaccardBounded([]int{1, 2, 3}, []int{2, 3, 4}, 0.6)
+	assert.True(ok)
+	assert.About(d, 0.5, 0.0001)
+
+	d, ok = slices.JaccardBounded([]int{1, 2, 3}, []int{2, 3, 4}, 0.4)
+	assert.False(ok)
+	assert.About(d, 0.5, 0.0001)
+
+	d, ok = slices.JaccardBounded([]int{1}, []int{1, 2, 3, 4}, 0.5)
+	assert.False(ok)
+	assert.About(d, 0.75, 0.0001)
+}
+
+// EOF
@@ -0,0 +1,84 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// FLATTENING
+//--------------------
+
+// FlatLength returns the number of all values of the nested slices. It's
+// the same as the length of the flattened slice without creating it.
+func FlatLength[V any](ivss [][]V) int {
+	l := 0
+	for _, vs := range ivss {
+		l += len(vs)
+	}
+	return l
+}
+
+// FlatMap calls fun() for each value of the slice and appends the returned
+// slices to one new slice like Append().
+func FlatMap[I, O any](ivs []I, fun func(I) []O) []O {
+	var ovs []O
+	for _, iv := range ivs {
+		if vs := fun(iv); vs != nil {
+			ovs = append(ovs, vs...)
+		}
+	}
+	return ovs
+}
+
+// Flatten appends the values of all nested slices to one new slice like
+// Append().
+func Flatten[V any](ivss [][]V) []V {
+	return Append(ivss...)
+}
+
+// FlattenDeep flattens a tree of values into one new slice. The function
+// children() returns the child values of a value, which are flattened
+// recursively. The values are ordered depth-first with each value preceding
+// its children.
+func FlattenDeep[V any](ivs []V, children func(V) []V) []V {
+	var ovs []V
+	var flatten func(vs []V)
+	flatten = func(vs []V) {
+		for _, v := range vs {
+			ovs = append(ovs, v)
+			flatten(children(v))
+		}
+	}
+	flatten(ivs)
+	return ovs
+}
+
+// Unflatten is the inverse of Flatten(). It splits the slice into nested
+// slices with the given lengths. If the lengths sum up to more than the
+// number of values the last slices are shorter or empty, if they sum up
+// to less the remaining values are dropped. Negative lengths are handled
+// like zero.
+func Unflatten[V any](ivs []V, lengths []int) [][]V {
+	if ivs == nil {
+		return nil
+	}
+	ovss := make([][]V, len(lengths))
+	pos := 0
+	for i, l := range lengths {
+		if l < 0 {
+			l = 0
+		}
+		if pos+l > len(ivs) {
+			l = len(ivs) - pos
+		}
+		ovss[i] = make([]V, l)
+		copy(ovss[i], ivs[pos:pos+l])
+		pos += l
+	}
+	return ovss
+}
+
+// EOF
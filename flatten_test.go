@@ -0,0 +1,198 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestFlatLength verifies the counting of nested slice values.
+func TestFlatLength(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values [][]int
+		out    int
+	}{
+		{
+			descr:  "Nested slices",
+			values: [][]int{{1, 2}, {3}, {}, nil, {4, 5, 6}},
+			out:    6,
+		}, {
+			descr:  "Empty slice",
+			values: [][]int{},
+			out:    0,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.FlatLength(test.values), test.out)
+	}
+}
+
+// TestFlatMap verifies the mapping of values to slices and their appending.
+func TestFlatMap(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	fun := func(v int) []int {
+		if v == 0 {
+			return nil
+		}
+		return []int{v, v * 10}
+	}
+	tests := []struct {
+		descr  string
+		values []int
+		out    []int
+	}{
+		{
+			descr:  "Multiple values",
+			values: []int{1, 2, 3},
+			out:    []int{1, 10, 2, 20, 3, 30},
+		}, {
+			descr:  "Values mapped to nil",
+			values: []int{0, 1, 0},
+			out:    []int{1, 10},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			out:    nil,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.FlatMap(test.values, fun), test.out)
+	}
+}
+
+// TestFlatten verifies the flattening of nested slices.
+func TestFlatten(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values [][]int
+		out    []int
+	}{
+		{
+			descr:  "Nested slices",
+			values: [][]int{{1, 2}, {3}, nil, {4, 5}},
+			out:    []int{1, 2, 3, 4, 5},
+		}, {
+			descr:  "Empty slice",
+			values: [][]int{},
+			out:    nil,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Flatten(test.values), test.out)
+	}
+}
+
+// TestFlattenDeep verifies the flattening of a tree of values.
+func TestFlattenDeep(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	type node struct {
+		name     string
+		children []node
+	}
+	children := func(n node) []node { return n.children }
+	name := func(n node) string { return n.name }
+	tree := []node{
+		{name: "a", children: []node{
+			{name: "b"},
+			{name: "c", children: []node{{name: "d"}}},
+		}},
+		{name: "e"},
+	}
+
+	assert.Equal(slices.Map(slices.FlattenDeep(tree, children), name), []string{"a", "b", "c", "d", "e"})
+	assert.Nil(slices.FlattenDeep([]node{}, children))
+	assert.Nil(slices.FlattenDeep(nil, children))
+}
+
+// TestUnflatten verifies the splitting of a slice by lengths.
+func TestUnflatten(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr   string
+		values  []int
+		lengths []int
+		out     [][]int
+	}{
+		{
+			descr:   "Matching lengths",
+			values:  []int{1, 2, 3, 4, 5},
+			lengths: []int{2, 0, 3},
+			out:     [][]int{{1, 2}, {}, {3, 4, 5}},
+		}, {
+			descr:   "Lengths too large",
+			values:  []int{1, 2, 3},
+			lengths: []int{2, 2, 2},
+			out:     [][]int{{1, 2}, {3}, {}},
+		}, {
+			descr:   "Lengths too small",
+			values:  []int{1, 2, 3, 4},
+			lengths: []int{1, 1},
+			out:     [][]int{{1}, {2}},
+		}, {
+			descr:   "Negative length",
+			values:  []int{1, 2, 3},
+			lengths: []int{1, -1, 2},
+			out:     [][]int{{1}, {}, {2, 3}},
+		}, {
+			descr:   "Nil slice",
+			values:  nil,
+			lengths: []int{1, 2},
+			out:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Unflatten(test.values, test.lengths), test.out)
+	}
+
+	// Unflatten is the inverse of Flatten.
+	nested := [][]int{{1, 2}, {3}, {4, 5, 6}}
+	lengths := slices.Map(nested, func(vs []int) int { return len(vs) })
+	assert.Equal(slices.Unflatten(slices.Flatten(nested), lengths), nested)
+}
+
+// EOF
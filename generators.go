@@ -0,0 +1,127 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// Number is the constraint for all integer and float types.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+//--------------------
+// GENERATORS
+//--------------------
+
+// Cycle returns a slice containing the values of the given slice n times.
+func Cycle[V any](ivs []V, n int) []V {
+	if ivs == nil {
+		return nil
+	}
+	if n < 0 {
+		n = 0
+	}
+	ovs := make([]V, 0, len(ivs)*n)
+	for i := 0; i < n; i++ {
+		ovs = append(ovs, ivs...)
+	}
+	return ovs
+}
+
+// Iterate returns a slice of n values starting with the seed. Each further
+// value is the result of fun() called with its predecessor.
+func Iterate[V any](seed V, fun func(V) V, n int) []V {
+	if n < 0 {
+		n = 0
+	}
+	ovs := make([]V, n)
+	for i := range ovs {
+		ovs[i] = seed
+		seed = fun(seed)
+	}
+	return ovs
+}
+
+// Repeat returns a slice containing the value v n times. It's the
+// counterpart of the Erlang lists:duplicate/2 function.
+func Repeat[V any](v V, n int) []V {
+	if n < 0 {
+		n = 0
+	}
+	ovs := make([]V, n)
+	for i := range ovs {
+		ovs[i] = v
+	}
+	return ovs
+}
+
+// Seq returns a slice of numbers starting at from and incremented by step as
+// long as to is not passed. A negative step creates a descending sequence,
+// so unsigned types can only create ascending ones. Floats are computed as
+// from plus a multiple of the step to avoid accumulating rounding errors.
+// A step of zero as well as a NaN or infinite argument returns nil.
+func Seq[V Number](from, to, step V) []V {
+	if step == 0 || !isFinite(from) || !isFinite(to) || !isFinite(step) {
+		return nil
+	}
+	ovs := []V{}
+	for i := 0; ; i++ {
+		v := from + V(i)*step
+		if (step > 0 && v > to) || (step < 0 && v < to) {
+			break
+		}
+		ovs = append(ovs, v)
+		// Stop before an integer overflow wraps around.
+		if (step > 0 && v > v+step) || (step < 0 && v < v+step) {
+			break
+		}
+	}
+	return ovs
+}
+
+// Unfold creates a slice out of a seed. The function fun() returns the
+// next value, the seed for the next call, and true. Returning false ends
+// the creation.
+func Unfold[S, V any](seed S, fun func(S) (V, S, bool)) []V {
+	ovs := []V{}
+	for {
+		v, next, ok := fun(seed)
+		if !ok {
+			return ovs
+		}
+		ovs = append(ovs, v)
+		seed = next
+	}
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// isNaN returns true if a number is a float NaN.
+func isNaN[V Number](v V) bool {
+	return v != v
+}
+
+// isFinite returns true if the value is neither NaN nor infinite. Integers
+// are always finite.
+func isFinite[V Number](v V) bool {
+	return v-v == 0
+}
+
+// EOF
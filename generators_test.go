@@ -0,0 +1,157 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestCycle verifies the repeating of slices.
+func TestCycle(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Cycle([]int{1, 2}, 3), []int{1, 2, 1, 2, 1, 2})
+	assert.Equal(slices.Cycle([]int{1, 2}, 0), []int{})
+	assert.Equal(slices.Cycle([]int{1, 2}, -1), []int{})
+	assert.Equal(slices.Cycle([]int{}, 3), []int{})
+	assert.Nil(slices.Cycle[int](nil, 3))
+}
+
+// TestIterate verifies the creation of slices by iterating a function.
+func TestIterate(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	double := func(v int) int { return v * 2 }
+
+	assert.Equal(slices.Iterate(1, double, 5), []int{1, 2, 4, 8, 16})
+	assert.Equal(slices.Iterate(1, double, 1), []int{1})
+	assert.Equal(slices.Iterate(1, double, 0), []int{})
+}
+
+// TestRepeat verifies the repeating of values.
+func TestRepeat(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Repeat("a", 3), []string{"a", "a", "a"})
+	assert.Equal(slices.Repeat("a", 0), []string{})
+	assert.Equal(slices.Repeat("a", -1), []string{})
+}
+
+// TestSeq verifies the creation of number sequences.
+func TestSeq(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr string
+		from  int
+		to    int
+		step  int
+		out   []int
+	}{
+		{
+			descr: "Ascending sequence",
+			from:  1,
+			to:    5,
+			step:  1,
+			out:   []int{1, 2, 3, 4, 5},
+		}, {
+			descr: "Ascending sequence with larger step",
+			from:  1,
+			to:    10,
+			step:  4,
+			out:   []int{1, 5, 9},
+		}, {
+			descr: "Descending sequence",
+			from:  5,
+			to:    1,
+			step:  -2,
+			out:   []int{5, 3, 1},
+		}, {
+			descr: "Single value sequence",
+			from:  3,
+			to:    3,
+			step:  1,
+			out:   []int{3},
+		}, {
+			descr: "Wrong direction",
+			from:  5,
+			to:    1,
+			step:  1,
+			out:   []int{},
+		}, {
+			descr: "Zero step",
+			from:  1,
+			to:    5,
+			step:  0,
+			out:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Seq(test.from, test.to, test.step), test.out)
+	}
+
+	fs := slices.Seq(0.0, 1.0, 0.1)
+	assert.Length(fs, 11)
+	assert.About(fs[10], 1.0, 0.000001)
+
+	assert.Equal(slices.Seq[int8](120, 127, 3), []int8{120, 123, 126})
+	assert.Equal(slices.Seq[uint8](250, 255, 5), []uint8{250, 255})
+
+	// NaN and infinite arguments would never end the sequence.
+	nan, inf := math.NaN(), math.Inf(1)
+	assert.Nil(slices.Seq(0.0, 1.0, nan))
+	assert.Nil(slices.Seq(0.0, nan, 0.1))
+	assert.Nil(slices.Seq(nan, 1.0, 0.1))
+	assert.Nil(slices.Seq(0.0, inf, 0.1))
+	assert.Nil(slices.Seq(0.0, -inf, -0.1))
+	assert.Nil(slices.Seq(-inf, 1.0, 0.1))
+	assert.Nil(slices.Seq(0.0, 1.0, inf))
+}
+
+// TestUnfold verifies the creation of slices by unfolding a seed.
+func TestUnfold(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	fibonacci := func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 20
+	}
+	assert.Equal(slices.Unfold([2]int{0, 1}, fibonacci), []int{0, 1, 1, 2, 3, 5, 8, 13})
+
+	never := func(s int) (int, int, bool) { return s, s, false }
+	assert.Equal(slices.Unfold(0, never), []int{})
+}
+
+// TestGeneratorsIntegration verifies the usage of generated slices with
+// the processing functions.
+func TestGeneratorsIntegration(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	sum := func(v, acc int) int { return acc + v }
+	square := func(v int) int { return v * v }
+
+	assert.Equal(slices.FoldL(slices.Seq(1, 100, 1), 0, sum), 5050)
+	assert.Equal(slices.Map(slices.Seq(1, 4, 1), square), []int{1, 4, 9, 16})
+	assert.Equal(slices.FoldL(slices.Repeat(3, 4), 0, sum), 12)
+}
+
+// EOF
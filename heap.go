@@ -0,0 +1,171 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// HEAP
+//--------------------
+
+// Heap is a binary min-heap ordered by a less function like the one of
+// SortWith(). The lowest value is always on top. Pushing and popping take
+// logarithmic time, so it can be used as priority queue.
+type Heap[V any] struct {
+	values []V
+	less   func(vs []V, i, j int) bool
+}
+
+// NewHeap creates a heap containing the values of the slice.
+func NewHeap[V any](ivs []V, less func(vs []V, i, j int) bool) *Heap[V] {
+	return &Heap[V]{
+		values: Heapify(ivs, less),
+		less:   less,
+	}
+}
+
+// Fix restores the heap order after the value at position i has been
+// changed. Positions out of range are ignored.
+func (h *Heap[V]) Fix(i int) {
+	if i < 0 || i >= len(h.values) {
+		return
+	}
+	if !siftDown(h.values, h.less, i, len(h.values)) {
+		siftUp(h.values, h.less, i)
+	}
+}
+
+// Len returns the number of values.
+func (h *Heap[V]) Len() int {
+	return len(h.values)
+}
+
+// Peek returns the lowest value without removing it. An empty heap
+// returns the default value and false.
+func (h *Heap[V]) Peek() (V, bool) {
+	if len(h.values) == 0 {
+		var v V
+		return v, false
+	}
+	return h.values[0], true
+}
+
+// Pop removes and returns the lowest value. An empty heap returns the
+// default value and false.
+func (h *Heap[V]) Pop() (V, bool) {
+	return h.Remove(0)
+}
+
+// Push adds values to the heap.
+func (h *Heap[V]) Push(vs ...V) {
+	for _, v := range vs {
+		h.values = append(h.values, v)
+		siftUp(h.values, h.less, len(h.values)-1)
+	}
+}
+
+// Remove removes and returns the value at position i. Positions out of
+// range return the default value and false.
+func (h *Heap[V]) Remove(i int) (V, bool) {
+	if i < 0 || i >= len(h.values) {
+		var v V
+		return v, false
+	}
+	last := len(h.values) - 1
+	v := h.values[i]
+	if i != last {
+		swap(h.values, i, last)
+	}
+	var zero V
+	h.values[last] = zero
+	h.values = h.values[:last]
+	h.Fix(i)
+	return v, true
+}
+
+// Set replaces the value at position i and restores the heap order.
+// Positions out of range are ignored.
+func (h *Heap[V]) Set(i int, v V) {
+	if i < 0 || i >= len(h.values) {
+		return
+	}
+	h.values[i] = v
+	h.Fix(i)
+}
+
+// ToSlice returns the values in heap order as a new slice.
+func (h *Heap[V]) ToSlice() []V {
+	return Copy(h.values)
+}
+
+//--------------------
+// HEAP FUNCTIONS
+//--------------------
+
+// Heapify returns a copy of the slice arranged as binary min-heap
+// ordered by the less function.
+func Heapify[V any](ivs []V, less func(vs []V, i, j int) bool) []V {
+	ovs := Copy(ivs)
+	for i := len(ovs)/2 - 1; i >= 0; i-- {
+		siftDown(ovs, less, i, len(ovs))
+	}
+	return ovs
+}
+
+// HeapSort returns a sorted copy of the given slice like SortWith().
+// Instead of the parallel quicksort it uses a heapsort, which needs
+// no additional memory and has a guaranteed O(n log n) runtime.
+func HeapSort[V any](ivs []V, less func(vs []V, i, j int) bool) []V {
+	// Build a max-heap by inverting the less function.
+	greater := func(vs []V, i, j int) bool {
+		return less(vs, j, i)
+	}
+	ovs := Heapify(ivs, greater)
+	for end := len(ovs) - 1; end > 0; end-- {
+		swap(ovs, 0, end)
+		siftDown(ovs, greater, 0, end)
+	}
+	return ovs
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// siftUp moves the value at position i up until the heap order is valid.
+func siftUp[V any](vs []V, less func(vs []V, i, j int) bool, i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !less(vs, i, parent) {
+			return
+		}
+		swap(vs, i, parent)
+		i = parent
+	}
+}
+
+// siftDown moves the value at position i down until the heap order of
+// the first n values is valid. It returns true if the value moved.
+func siftDown[V any](vs []V, less func(vs []V, i, j int) bool, i, n int) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && less(vs, right, child) {
+			child = right
+		}
+		if !less(vs, child, i) {
+			break
+		}
+		swap(vs, i, child)
+		i = child
+	}
+	return i > start
+}
+
+// EOF
@@ -0,0 +1,147 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestHeapPushPop verifies the ordered popping of pushed values.
+func TestHeapPushPop(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	less := func(vs []int, i, j int) bool { return vs[i] < vs[j] }
+	h := slices.NewHeap([]int{5, 3, 8}, less)
+	h.Push(1, 9, 4)
+	assert.Equal(h.Len(), 6)
+
+	v, ok := h.Peek()
+	assert.True(ok)
+	assert.Equal(v, 1)
+
+	popped := []int{}
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		popped = append(popped, v)
+	}
+	assert.Equal(popped, []int{1, 3, 4, 5, 8, 9})
+
+	_, ok = h.Peek()
+	assert.False(ok)
+	_, ok = h.Pop()
+	assert.False(ok)
+}
+
+// TestHeapFixRemove verifies the changing and removing of values.
+func TestHeapFixRemove(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	type task struct {
+		name     string
+		priority int
+	}
+	less := func(vs []*task, i, j int) bool { return vs[i].priority < vs[j].priority }
+	a := &task{"a", 5}
+	b := &task{"b", 3}
+	c := &task{"c", 7}
+	h := slices.NewHeap([]*task{a, b, c}, less)
+
+	// Change priority in place and fix the position.
+	pos := slices.IndexOf(h.ToSlice(), c)
+	c.priority = 1
+	h.Fix(pos)
+	top, _ := h.Peek()
+	assert.Equal(top.name, "c")
+
+	// Replace a value.
+	h.Set(0, &task{"d", 10})
+	top, _ = h.Peek()
+	assert.Equal(top.name, "b")
+
+	// Remove a value.
+	pos = slices.IndexOf(h.ToSlice(), a)
+	removed, ok := h.Remove(pos)
+	assert.True(ok)
+	assert.Equal(removed.name, "a")
+	_, ok = h.Remove(10)
+	assert.False(ok)
+	names := []string{}
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		names = append(names, v.name)
+	}
+	assert.Equal(names, []string{"b", "d"})
+}
+
+// TestHeapify verifies the arranging of a slice as heap.
+func TestHeapify(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	less := func(vs []int, i, j int) bool { return vs[i] < vs[j] }
+	gen := generators.New(generators.FixedRand())
+	values := gen.Ints(0, 1000, 500)
+	heap := slices.Heapify(values, less)
+
+	assert.Length(heap, len(values))
+	for i := 1; i < len(heap); i++ {
+		assert.True(heap[(i-1)/2] <= heap[i])
+	}
+	assert.Nil(slices.Heapify(nil, less))
+}
+
+// TestHeapSort verifies the sorting with heapsort.
+func TestHeapSort(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	less := func(vs []int, i, j int) bool { return vs[i] < vs[j] }
+	gen := generators.New(generators.FixedRand())
+	values := gen.Ints(0, 1000, 5000)
+
+	assert.Equal(slices.HeapSort(values, less), slices.Sort(values))
+	assert.Equal(slices.HeapSort([]int{3, 1, 2}, less), []int{1, 2, 3})
+	assert.Equal(slices.HeapSort([]int{}, less), []int{})
+	assert.Nil(slices.HeapSort(nil, less))
+
+	// Top-N selection.
+	h := slices.NewHeap(values, func(vs []int, i, j int) bool { return vs[i] > vs[j] })
+	top := []int{}
+	for i := 0; i < 3; i++ {
+		v, _ := h.Pop()
+		top = append(top, v)
+	}
+	sorted := slices.Sort(values)
+	assert.Equal(top, slices.Reverse(sorted[len(sorted)-3:]))
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkHeapSort runs a performance test on heapsort.
+func BenchmarkHeapSort(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vs := gen.Ints(0, 1000, 10000)
+	less := func(vs []int, i, j int) bool { return vs[i] < vs[j] }
+
+	for i := 0; i < b.N; i++ {
+		slices.HeapSort(vs, less)
+	}
+}
+
+// EOF
@@ -0,0 +1,130 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// INDEX
+//--------------------
+
+// FindAll returns the indices of all values where pred() returns true.
+func FindAll[V any](ivs []V, pred func(V) bool) []int {
+	if ivs == nil {
+		return nil
+	}
+	idxs := []int{}
+	for i, v := range ivs {
+		if pred(v) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
+
+// IndexOf returns the index of the first value equal to v or -1 if the
+// slice does not contain it.
+func IndexOf[V comparable](ivs []V, v V) int {
+	return IndexWith(ivs, func(iv V) bool {
+		return iv == v
+	})
+}
+
+// IndexWith returns the index of the first value where pred() returns true
+// or -1 if there's none.
+func IndexWith[V any](ivs []V, pred func(V) bool) int {
+	for i, v := range ivs {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsInfix returns true if the first slice is contained contiguously in
+// the second one.
+func IsInfix[V comparable](infix, all []V) bool {
+	return SubsliceIndex(all, infix) >= 0
+}
+
+// LastIndexOf returns the index of the last value equal to v or -1 if the
+// slice does not contain it.
+func LastIndexOf[V comparable](ivs []V, v V) int {
+	return LastIndexWith(ivs, func(iv V) bool {
+		return iv == v
+	})
+}
+
+// LastIndexWith returns the index of the last value where pred() returns true
+// or -1 if there's none.
+func LastIndexWith[V any](ivs []V, pred func(V) bool) int {
+	for i := len(ivs) - 1; i >= 0; i-- {
+		if pred(ivs[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SearchLast returns the last value that satisfies the given predicate.
+func SearchLast[V any](pred func(v V) bool, ivs []V) (V, bool) {
+	if i := LastIndexWith(ivs, pred); i >= 0 {
+		return ivs[i], true
+	}
+	// Return default value and false.
+	var ov V
+	return ov, false
+}
+
+// SubsliceIndex returns the index of the first occurrence of the pattern
+// in the slice or -1 if it is not contained. An empty pattern is found at
+// index 0. The search uses the Knuth-Morris-Pratt algorithm.
+func SubsliceIndex[V comparable](ivs, pattern []V) int {
+	if len(pattern) == 0 {
+		return 0
+	}
+	if len(pattern) > len(ivs) {
+		return -1
+	}
+	failure := kmpFailure(pattern)
+	matched := 0
+	for i, v := range ivs {
+		for matched > 0 && v != pattern[matched] {
+			matched = failure[matched-1]
+		}
+		if v == pattern[matched] {
+			matched++
+		}
+		if matched == len(pattern) {
+			return i - matched + 1
+		}
+	}
+	return -1
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// kmpFailure computes the failure function of the Knuth-Morris-Pratt
+// algorithm. Each entry contains the length of the longest proper prefix
+// of the pattern up to this position which is also its suffix.
+func kmpFailure[V comparable](pattern []V) []int {
+	failure := make([]int, len(pattern))
+	matched := 0
+	for i := 1; i < len(pattern); i++ {
+		for matched > 0 && pattern[i] != pattern[matched] {
+			matched = failure[matched-1]
+		}
+		if pattern[i] == pattern[matched] {
+			matched++
+		}
+		failure[i] = matched
+	}
+	return failure
+}
+
+// EOF
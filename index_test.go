@@ -0,0 +1,242 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestFindAll verifies the finding of all matching indices.
+func TestFindAll(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	pred := func(v int) bool { return v%2 == 0 }
+	tests := []struct {
+		descr  string
+		values []int
+		out    []int
+	}{
+		{
+			descr:  "Multiple matches",
+			values: []int{1, 2, 3, 4, 5, 6},
+			out:    []int{1, 3, 5},
+		}, {
+			descr:  "No matches",
+			values: []int{1, 3, 5},
+			out:    []int{},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			out:    []int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.FindAll(test.values, pred), test.out)
+	}
+}
+
+// TestIndexOf verifies the finding of first and last indices of values.
+func TestIndexOf(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		value  int
+		first  int
+		last   int
+	}{
+		{
+			descr:  "Single occurrence",
+			values: []int{1, 2, 3, 4},
+			value:  3,
+			first:  2,
+			last:   2,
+		}, {
+			descr:  "Multiple occurrences",
+			values: []int{1, 2, 1, 2, 1},
+			value:  2,
+			first:  1,
+			last:   3,
+		}, {
+			descr:  "Uncontained value",
+			values: []int{1, 2, 3},
+			value:  4,
+			first:  -1,
+			last:   -1,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			value:  1,
+			first:  -1,
+			last:   -1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.IndexOf(test.values, test.value), test.first)
+		assert.Equal(slices.LastIndexOf(test.values, test.value), test.last)
+	}
+}
+
+// TestIndexWith verifies the finding of first and last indices by predicate.
+func TestIndexWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	pred := func(s string) bool { return len(s) > 3 }
+	values := []string{"a", "alpha", "bb", "beta", "c"}
+
+	assert.Equal(slices.IndexWith(values, pred), 1)
+	assert.Equal(slices.LastIndexWith(values, pred), 3)
+	assert.Equal(slices.IndexWith([]string{"a", "b"}, pred), -1)
+	assert.Equal(slices.LastIndexWith([]string{"a", "b"}, pred), -1)
+	assert.Equal(slices.IndexWith(nil, pred), -1)
+	assert.Equal(slices.LastIndexWith(nil, pred), -1)
+}
+
+// TestIsInfix verifies the check of contained subslices.
+func TestIsInfix(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		infix  []int
+		values []int
+		out    bool
+	}{
+		{
+			descr:  "Infix in the middle",
+			infix:  []int{3, 4},
+			values: []int{1, 2, 3, 4, 5},
+			out:    true,
+		}, {
+			descr:  "Infix as prefix",
+			infix:  []int{1, 2},
+			values: []int{1, 2, 3},
+			out:    true,
+		}, {
+			descr:  "Infix as suffix",
+			infix:  []int{2, 3},
+			values: []int{1, 2, 3},
+			out:    true,
+		}, {
+			descr:  "Interrupted infix",
+			infix:  []int{2, 4},
+			values: []int{1, 2, 3, 4},
+			out:    false,
+		}, {
+			descr:  "Infix longer than slice",
+			infix:  []int{1, 2, 3},
+			values: []int{1, 2},
+			out:    false,
+		}, {
+			descr:  "Empty infix",
+			infix:  []int{},
+			values: []int{1, 2},
+			out:    true,
+		}, {
+			descr:  "Nil slices",
+			infix:  nil,
+			values: nil,
+			out:    true,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.IsInfix(test.infix, test.values), test.out)
+	}
+}
+
+// TestSearchLast verifies the searching of the last matching value.
+func TestSearchLast(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	pred := func(s string) bool { return len(s) > 3 }
+
+	v, ok := slices.SearchLast(pred, []string{"a", "alpha", "bb", "beta", "c"})
+	assert.True(ok)
+	assert.Equal(v, "beta")
+
+	v, ok = slices.SearchLast(pred, []string{"a", "b"})
+	assert.False(ok)
+	assert.Equal(v, "")
+
+	_, ok = slices.SearchLast(pred, nil)
+	assert.False(ok)
+}
+
+// TestSubsliceIndex verifies the finding of contiguous patterns.
+func TestSubsliceIndex(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr   string
+		values  []int
+		pattern []int
+		out     int
+	}{
+		{
+			descr:   "Pattern in the middle",
+			values:  []int{1, 2, 3, 4, 5},
+			pattern: []int{3, 4},
+			out:     2,
+		}, {
+			descr:   "Pattern with partial matches before",
+			values:  []int{1, 1, 2, 1, 1, 2, 1, 1, 1, 2},
+			pattern: []int{1, 1, 1, 2},
+			out:     6,
+		}, {
+			descr:   "First of multiple occurrences",
+			values:  []int{5, 1, 2, 5, 1, 2},
+			pattern: []int{1, 2},
+			out:     1,
+		}, {
+			descr:   "Uncontained pattern",
+			values:  []int{1, 2, 3},
+			pattern: []int{3, 2},
+			out:     -1,
+		}, {
+			descr:   "Empty pattern",
+			values:  []int{1, 2, 3},
+			pattern: []int{},
+			out:     0,
+		}, {
+			descr:   "Nil slice",
+			values:  nil,
+			pattern: []int{1},
+			out:     -1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.SubsliceIndex(test.values, test.pattern), test.out)
+	}
+}
+
+// EOF
@@ -0,0 +1,50 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// ITERATOR
+//--------------------
+
+// Iterator lazily returns one value after the other with each call. When
+// no more values are available it returns the default value and false.
+type Iterator[V any] func() (V, bool)
+
+// Collect reads all values of the iterator into a new slice. An iterator
+// without any value returns nil.
+func Collect[V any](it Iterator[V]) []V {
+	var ovs []V
+	for v, ok := it(); ok; v, ok = it() {
+		ovs = append(ovs, v)
+	}
+	return ovs
+}
+
+// Values returns an iterator over the values of the slice.
+func Values[V any](ivs []V) Iterator[V] {
+	i := 0
+	return func() (V, bool) {
+		if i >= len(ivs) {
+			return exhausted[V]()
+		}
+		i++
+		return ivs[i-1], true
+	}
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// exhausted returns the default value and false like an exhausted iterator.
+func exhausted[V any]() (V, bool) {
+	var v V
+	return v, false
+}
+
+// EOF
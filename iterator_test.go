@@ -0,0 +1,42 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestValues verifies iterating over slices.
+func TestValues(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	it := slices.Values([]int{1, 2, 3})
+	v, ok := it()
+	assert.True(ok)
+	assert.Equal(v, 1)
+	assert.Equal(slices.Collect(it), []int{2, 3})
+	_, ok = it()
+	assert.False(ok)
+
+	assert.Nil(slices.Collect(slices.Values([]int{})))
+	assert.Nil(slices.Collect(slices.Values[int](nil)))
+}
+
+// EOF
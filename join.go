@@ -0,0 +1,240 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// JoinKind defines which values of two slices are combined by a join.
+type JoinKind int
+
+// Join kinds.
+const (
+	// JoinInner combines left and right values with equal keys.
+	JoinInner JoinKind = iota
+
+	// JoinLeft works like JoinInner but also keeps left values
+	// without a matching right value.
+	JoinLeft
+
+	// JoinRight works like JoinInner but also keeps right values
+	// without a matching left value.
+	JoinRight
+
+	// JoinFullOuter works like JoinInner but also keeps left and
+	// right values without a matching value.
+	JoinFullOuter
+
+	// JoinSemi keeps each left value having a matching right value
+	// once.
+	JoinSemi
+
+	// JoinAnti keeps each left value without a matching right value.
+	JoinAnti
+)
+
+//--------------------
+// JOINS
+//--------------------
+
+// AntiJoin returns the left values without a right value with an equal key.
+func AntiJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []L {
+	return JoinRecords(JoinAnti, ls, rs, lkey, rkey, leftValue[L, R])
+}
+
+// FullOuterJoin returns pairs of all left and right values with equal keys.
+// Values without a match are paired with nil.
+func FullOuterJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []Pair[*L, *R] {
+	return JoinRecords(JoinFullOuter, ls, rs, lkey, rkey, func(l *L, r *R) Pair[*L, *R] {
+		return Pair[*L, *R]{l, r}
+	})
+}
+
+// InnerJoin returns pairs of all left and right values with equal keys.
+func InnerJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []Pair[L, R] {
+	return JoinRecords(JoinInner, ls, rs, lkey, rkey, func(l *L, r *R) Pair[L, R] {
+		return Pair[L, R]{*l, *r}
+	})
+}
+
+// LeftJoin returns pairs of all left and right values with equal keys.
+// Left values without a match are paired with nil.
+func LeftJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []Pair[L, *R] {
+	return JoinRecords(JoinLeft, ls, rs, lkey, rkey, func(l *L, r *R) Pair[L, *R] {
+		return Pair[L, *R]{*l, r}
+	})
+}
+
+// RightJoin returns pairs of all left and right values with equal keys.
+// Right values without a match are paired with nil.
+func RightJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []Pair[*L, R] {
+	return JoinRecords(JoinRight, ls, rs, lkey, rkey, func(l *L, r *R) Pair[*L, R] {
+		return Pair[*L, R]{l, *r}
+	})
+}
+
+// SemiJoin returns the left values having a right value with an equal key.
+func SemiJoin[L, R any, K comparable](ls []L, rs []R, lkey func(L) K, rkey func(R) K) []L {
+	return JoinRecords(JoinSemi, ls, rs, lkey, rkey, leftValue[L, R])
+}
+
+// JoinRecords joins the left and right values with equal keys as defined by
+// the kind. It's done as hash join, so the inputs don't need to be sorted.
+// The function combine() creates the result out of copies of the matching
+// values. A missing value is passed as nil, for semi and anti joins the
+// right value is always nil. The results are ordered like the left values,
+// right values without a match follow in their order. A right join is
+// ordered like the right values.
+func JoinRecords[L, R any, K comparable, O any](
+	kind JoinKind,
+	ls []L,
+	rs []R,
+	lkey func(L) K,
+	rkey func(R) K,
+	combine func(*L, *R) O,
+) []O {
+	if kind == JoinRight {
+		return JoinRecords(JoinLeft, rs, ls, rkey, lkey, func(r *R, l *L) O {
+			return combine(l, r)
+		})
+	}
+	if ls == nil && rs == nil {
+		return nil
+	}
+	index := map[K][]int{}
+	for j, r := range rs {
+		k := rkey(r)
+		index[k] = append(index[k], j)
+	}
+	matched := make([]bool, len(rs))
+	ovs := []O{}
+	for _, l := range ls {
+		l := l
+		js := index[lkey(l)]
+		switch {
+		case kind == JoinSemi:
+			if len(js) > 0 {
+				ovs = append(ovs, combine(&l, nil))
+			}
+		case kind == JoinAnti:
+			if len(js) == 0 {
+				ovs = append(ovs, combine(&l, nil))
+			}
+		case len(js) == 0:
+			if kind == JoinLeft || kind == JoinFullOuter {
+				ovs = append(ovs, combine(&l, nil))
+			}
+		default:
+			for _, j := range js {
+				r := rs[j]
+				matched[j] = true
+				ovs = append(ovs, combine(&l, &r))
+			}
+		}
+	}
+	if kind == JoinFullOuter {
+		for j, r := range rs {
+			r := r
+			if !matched[j] {
+				ovs = append(ovs, combine(nil, &r))
+			}
+		}
+	}
+	return ovs
+}
+
+// MergeJoinRecords works like JoinRecords() but expects both slices sorted
+// in ascending order of their keys. So it's done in one pass as merge join
+// without an index. The results are ordered by the keys, values with
+// equal keys are ordered like in the slices. Unsorted inputs lead to
+// missing results.
+func MergeJoinRecords[L, R any, K constraints.Ordered, O any](
+	kind JoinKind,
+	ls []L,
+	rs []R,
+	lkey func(L) K,
+	rkey func(R) K,
+	combine func(*L, *R) O,
+) []O {
+	if kind == JoinRight {
+		return MergeJoinRecords(JoinLeft, rs, ls, rkey, lkey, func(r *R, l *L) O {
+			return combine(l, r)
+		})
+	}
+	if ls == nil && rs == nil {
+		return nil
+	}
+	keepLeft := kind == JoinLeft || kind == JoinFullOuter || kind == JoinAnti
+	keepRight := kind == JoinFullOuter
+	ovs := []O{}
+	i, j := 0, 0
+	for i < len(ls) || j < len(rs) {
+		switch {
+		case j == len(rs) || (i < len(ls) && lkey(ls[i]) < rkey(rs[j])):
+			// Left value without match.
+			if keepLeft {
+				l := ls[i]
+				ovs = append(ovs, combine(&l, nil))
+			}
+			i++
+		case i == len(ls) || rkey(rs[j]) < lkey(ls[i]):
+			// Right value without match.
+			if keepRight {
+				r := rs[j]
+				ovs = append(ovs, combine(nil, &r))
+			}
+			j++
+		default:
+			// Find the groups of equal keys.
+			k := lkey(ls[i])
+			iend, jend := i+1, j+1
+			for iend < len(ls) && lkey(ls[iend]) == k {
+				iend++
+			}
+			for jend < len(rs) && rkey(rs[jend]) == k {
+				jend++
+			}
+			for ; i < iend; i++ {
+				l := ls[i]
+				switch kind {
+				case JoinSemi:
+					ovs = append(ovs, combine(&l, nil))
+				case JoinAnti:
+					// Matching values are dropped.
+				default:
+					for jj := j; jj < jend; jj++ {
+						r := rs[jj]
+						ovs = append(ovs, combine(&l, &r))
+					}
+				}
+			}
+			j = jend
+		}
+	}
+	return ovs
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// leftValue returns the left value of a join.
+func leftValue[L, R any](l *L, r *R) L {
+	return *l
+}
+
+// EOF
@@ -0,0 +1,160 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"fmt"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// User and Order are record types for testing the joins.
+type User struct {
+	ID   int
+	Name string
+}
+
+type Order struct {
+	UserID int
+	Item   string
+}
+
+var (
+	users = []User{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	// Orders are sorted by user ID.
+	orders = []Order{{1, "book"}, {1, "pen"}, {3, "lamp"}, {4, "desk"}}
+)
+
+// userID and orderUserID return the join keys.
+func userID(u User) int       { return u.ID }
+func orderUserID(o Order) int { return o.UserID }
+
+// describe creates a readable join result.
+func describe(u *User, o *Order) string {
+	name, item := "-", "-"
+	if u != nil {
+		name = u.Name
+	}
+	if o != nil {
+		item = o.Item
+	}
+	return fmt.Sprintf("%s:%s", name, item)
+}
+
+// TestInnerJoin verifies the joining of matching values.
+func TestInnerJoin(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.InnerJoin(users, orders, userID, orderUserID), []slices.Pair[User, Order]{
+		{User{1, "alice"}, Order{1, "book"}},
+		{User{1, "alice"}, Order{1, "pen"}},
+		{User{3, "carol"}, Order{3, "lamp"}},
+	})
+	assert.Equal(slices.InnerJoin(users, []Order{}, userID, orderUserID), []slices.Pair[User, Order]{})
+	assert.Nil(slices.InnerJoin[User, Order](nil, nil, userID, orderUserID))
+}
+
+// TestOuterJoins verifies the joining keeping unmatched values.
+func TestOuterJoins(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	left := slices.LeftJoin(users, orders, userID, orderUserID)
+	assert.Length(left, 4)
+	assert.Equal(left[2].First, User{2, "bob"})
+	assert.Nil(left[2].Second)
+	assert.Equal(*left[3].Second, Order{3, "lamp"})
+
+	right := slices.RightJoin(users, orders, userID, orderUserID)
+	assert.Length(right, 4)
+	assert.Equal(*right[0].First, User{1, "alice"})
+	assert.Nil(right[3].First)
+	assert.Equal(right[3].Second, Order{4, "desk"})
+
+	full := slices.FullOuterJoin(users, orders, userID, orderUserID)
+	assert.Equal(slices.Map(full, func(p slices.Pair[*User, *Order]) string {
+		return describe(p.First, p.Second)
+	}), []string{"alice:book", "alice:pen", "bob:-", "carol:lamp", "-:desk"})
+}
+
+// TestSemiAntiJoin verifies the filtering by existing matches.
+func TestSemiAntiJoin(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.SemiJoin(users, orders, userID, orderUserID), []User{{1, "alice"}, {3, "carol"}})
+	assert.Equal(slices.AntiJoin(users, orders, userID, orderUserID), []User{{2, "bob"}})
+	assert.Equal(slices.AntiJoin(users, nil, userID, orderUserID), users)
+}
+
+// TestJoinWith verifies the combining of joined values.
+func TestJoinWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		kind slices.JoinKind
+		out  []string
+	}{
+		{slices.JoinInner, []string{"alice:book", "alice:pen", "carol:lamp"}},
+		{slices.JoinLeft, []string{"alice:book", "alice:pen", "bob:-", "carol:lamp"}},
+		{slices.JoinRight, []string{"alice:book", "alice:pen", "carol:lamp", "-:desk"}},
+		{slices.JoinFullOuter, []string{"alice:book", "alice:pen", "bob:-", "carol:lamp", "-:desk"}},
+		{slices.JoinSemi, []string{"alice:-", "carol:-"}},
+		{slices.JoinAnti, []string{"bob:-"}},
+	}
+
+	for _, test := range tests {
+		assert.Logf("join kind %d", test.kind)
+		assert.Equal(slices.JoinRecords(test.kind, users, orders, userID, orderUserID, describe), test.out)
+		// Merge join of sorted inputs has the same results.
+		assert.Equal(slices.MergeJoinRecords(test.kind, users, orders, userID, orderUserID, describe), test.out)
+	}
+}
+
+// TestMergeJoinRecords verifies the merge join with multiple equal keys on
+// both sides.
+func TestMergeJoinRecords(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ls := []int{1, 2, 2, 5}
+	rs := []int{2, 2, 3, 5, 6}
+	same := func(v int) int { return v }
+	combine := func(l, r *int) string {
+		return describe(
+			func() *User {
+				if l == nil {
+					return nil
+				}
+				return &User{Name: fmt.Sprint(*l)}
+			}(),
+			func() *Order {
+				if r == nil {
+					return nil
+				}
+				return &Order{Item: fmt.Sprint(*r)}
+			}(),
+		)
+	}
+
+	assert.Equal(slices.MergeJoinRecords(slices.JoinFullOuter, ls, rs, same, same, combine),
+		[]string{"1:-", "2:2", "2:2", "2:2", "2:2", "-:3", "5:5", "-:6"})
+	assert.Equal(slices.MergeJoinRecords(slices.JoinSemi, ls, rs, same, same, combine),
+		[]string{"2:-", "2:-", "5:-"})
+	assert.Nil(slices.MergeJoinRecords(slices.JoinInner, nil, nil, same, same, combine))
+}
+
+// EOF
@@ -0,0 +1,140 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// KEYS
+//--------------------
+
+// KeyDelete removes the first value of the slice where the key function
+// returns the key k.
+func KeyDelete[V any, K comparable](ivs []V, key func(V) K, k K) []V {
+	return DeleteWith(ivs, func(v V) bool {
+		return key(v) == k
+	})
+}
+
+// KeyFind returns the first value of the slice where the key function
+// returns the key k.
+func KeyFind[V any, K comparable](ivs []V, key func(V) K, k K) (V, bool) {
+	return Search(func(v V) bool {
+		return key(v) == k
+	}, ivs)
+}
+
+// KeyMap sets the key of each value of the slice to the result of fun().
+// The function set() has to return a copy of the value with the new key.
+func KeyMap[V, K any](ivs []V, key func(V) K, set func(V, K) V, fun func(K) K) []V {
+	return Map(ivs, func(v V) V {
+		return set(v, fun(key(v)))
+	})
+}
+
+// KeyMember returns true if the slice contains a value where the key
+// function returns the key k.
+func KeyMember[V any, K comparable](ivs []V, key func(V) K, k K) bool {
+	_, ok := KeyFind(ivs, key, k)
+	return ok
+}
+
+// KeyMerge merges two slices already sorted by the key function into one
+// sorted slice. Opposite to MergeWith() the slices are not sorted again
+// but merged in one pass. For equal keys the values of the first slice
+// are taken first.
+func KeyMerge[V any, K constraints.Ordered](vsa, vsb []V, key func(V) K) []V {
+	if vsa == nil && vsb == nil {
+		return nil
+	}
+	ovs := make([]V, 0, len(vsa)+len(vsb))
+	i, j := 0, 0
+	for i < len(vsa) && j < len(vsb) {
+		if key(vsb[j]) < key(vsa[i]) {
+			ovs = append(ovs, vsb[j])
+			j++
+		} else {
+			ovs = append(ovs, vsa[i])
+			i++
+		}
+	}
+	ovs = append(ovs, vsa[i:]...)
+	return append(ovs, vsb[j:]...)
+}
+
+// KeyReplace replaces the first value of the slice where the key function
+// returns the key k with the new value nv.
+func KeyReplace[V any, K comparable](ivs []V, key func(V) K, k K, nv V) []V {
+	ovs := Copy(ivs)
+	for i := range ovs {
+		if key(ovs[i]) == k {
+			ovs[i] = nv
+			return ovs
+		}
+	}
+	return ovs
+}
+
+// KeySort returns a copy of the slice sorted by the key function. Opposite
+// to SortWith() the sorting is stable, values with equal keys keep their
+// order.
+func KeySort[V any, K constraints.Ordered](ivs []V, key func(V) K) []V {
+	if ivs == nil {
+		return nil
+	}
+	type keyed struct {
+		k   K
+		pos int
+	}
+	kvs := make([]keyed, len(ivs))
+	for i, v := range ivs {
+		kvs[i] = keyed{key(v), i}
+	}
+	less := func(vs []keyed, i, j int) bool {
+		if vs[i].k == vs[j].k {
+			return vs[i].pos < vs[j].pos
+		}
+		return vs[i].k < vs[j].k
+	}
+	return Map(SortWith(kvs, less), func(kv keyed) V {
+		return ivs[kv.pos]
+	})
+}
+
+// KeyStore replaces the first value of the slice where the key function
+// returns the key k with the new value nv. If no such value exists nv
+// is appended.
+func KeyStore[V any, K comparable](ivs []V, key func(V) K, k K, nv V) []V {
+	if !KeyMember(ivs, key, k) {
+		return append(Copy(ivs), nv)
+	}
+	return KeyReplace(ivs, key, k, nv)
+}
+
+// KeyTake returns the first value of the slice where the key function returns
+// the key k and a copy of the slice without this value.
+func KeyTake[V any, K comparable](ivs []V, key func(V) K, k K) (V, []V, bool) {
+	for i, v := range ivs {
+		if key(v) == k {
+			ovs := make([]V, 0, len(ivs)-1)
+			ovs = append(ovs, ivs[:i]...)
+			return v, append(ovs, ivs[i+1:]...), true
+		}
+	}
+	// Return default value and unchanged copy.
+	var ov V
+	return ov, Copy(ivs), false
+}
+
+// EOF
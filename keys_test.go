@@ -0,0 +1,241 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strings"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// KV is a simple key/value type for testing the key functions.
+type KV struct {
+	k int
+	v string
+}
+
+// key returns the key of a KV.
+func key(kv KV) int {
+	return kv.k
+}
+
+// TestKeyDelete verifies the deleting of a value by key.
+func TestKeyDelete(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []KV
+		k      int
+		out    []KV
+	}{
+		{
+			descr:  "Delete first matching value",
+			values: []KV{{1, "one"}, {2, "two"}, {2, "zwei"}},
+			k:      2,
+			out:    []KV{{1, "one"}, {2, "zwei"}},
+		}, {
+			descr:  "Delete uncontained key",
+			values: []KV{{1, "one"}, {2, "two"}},
+			k:      3,
+			out:    []KV{{1, "one"}, {2, "two"}},
+		}, {
+			descr:  "Empty slice",
+			values: []KV{},
+			k:      1,
+			out:    []KV{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			k:      1,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.KeyDelete(test.values, key, test.k), test.out)
+	}
+}
+
+// TestKeyFind verifies the finding of a value by key.
+func TestKeyFind(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []KV{{1, "one"}, {2, "two"}, {2, "zwei"}}
+
+	v, ok := slices.KeyFind(values, key, 2)
+	assert.True(ok)
+	assert.Equal(v, KV{2, "two"})
+
+	v, ok = slices.KeyFind(values, key, 3)
+	assert.False(ok)
+	assert.Equal(v, KV{})
+
+	_, ok = slices.KeyFind(nil, key, 1)
+	assert.False(ok)
+}
+
+// TestKeyMap verifies the mapping of the keys of values.
+func TestKeyMap(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	name := func(kv KV) string { return kv.v }
+	set := func(kv KV, v string) KV { return KV{kv.k, v} }
+
+	assert.Equal(slices.KeyMap([]KV{{1, "one"}, {2, "two"}}, name, set, strings.ToUpper),
+		[]KV{{1, "ONE"}, {2, "TWO"}})
+	assert.Equal(slices.KeyMap([]KV{}, name, set, strings.ToUpper), []KV{})
+	assert.Nil(slices.KeyMap(nil, name, set, strings.ToUpper))
+}
+
+// TestKeyMember verifies the testing of a value by key.
+func TestKeyMember(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []KV{{1, "one"}, {2, "two"}}
+
+	assert.True(slices.KeyMember(values, key, 1))
+	assert.False(slices.KeyMember(values, key, 3))
+	assert.False(slices.KeyMember(nil, key, 1))
+}
+
+// TestKeyMerge verifies the merging of slices sorted by key.
+func TestKeyMerge(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr   string
+		valuesA []KV
+		valuesB []KV
+		out     []KV
+	}{
+		{
+			descr:   "Overlapping slices",
+			valuesA: []KV{{1, "a"}, {3, "a"}, {5, "a"}},
+			valuesB: []KV{{2, "b"}, {3, "b"}, {6, "b"}},
+			out:     []KV{{1, "a"}, {2, "b"}, {3, "a"}, {3, "b"}, {5, "a"}, {6, "b"}},
+		}, {
+			descr:   "Empty first slice",
+			valuesA: []KV{},
+			valuesB: []KV{{1, "b"}},
+			out:     []KV{{1, "b"}},
+		}, {
+			descr:   "Nil second slice",
+			valuesA: []KV{{1, "a"}},
+			valuesB: nil,
+			out:     []KV{{1, "a"}},
+		}, {
+			descr:   "Both slices empty",
+			valuesA: []KV{},
+			valuesB: []KV{},
+			out:     []KV{},
+		}, {
+			descr:   "Nil slices",
+			valuesA: nil,
+			valuesB: nil,
+			out:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.KeyMerge(test.valuesA, test.valuesB, key), test.out)
+	}
+}
+
+// TestKeyReplace verifies the replacing of a value by key.
+func TestKeyReplace(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []KV{{1, "one"}, {2, "two"}, {2, "zwei"}}
+
+	assert.Equal(slices.KeyReplace(values, key, 2, KV{2, "deux"}), []KV{{1, "one"}, {2, "deux"}, {2, "zwei"}})
+	assert.Equal(slices.KeyReplace(values, key, 3, KV{3, "three"}), values)
+	assert.Equal(values, []KV{{1, "one"}, {2, "two"}, {2, "zwei"}})
+	assert.Nil(slices.KeyReplace(nil, key, 1, KV{1, "one"}))
+}
+
+// TestKeySort verifies the stable sorting by key.
+func TestKeySort(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []KV
+		out    []KV
+	}{
+		{
+			descr:  "Unordered slice",
+			values: []KV{{3, "three"}, {1, "one"}, {2, "two"}},
+			out:    []KV{{1, "one"}, {2, "two"}, {3, "three"}},
+		}, {
+			descr:  "Equal keys keep order",
+			values: []KV{{2, "b"}, {1, "a"}, {2, "a"}, {1, "b"}, {2, "c"}},
+			out:    []KV{{1, "a"}, {1, "b"}, {2, "b"}, {2, "a"}, {2, "c"}},
+		}, {
+			descr:  "Empty slice",
+			values: []KV{},
+			out:    []KV{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.KeySort(test.values, key), test.out)
+	}
+}
+
+// TestKeyStore verifies the storing of a value by key.
+func TestKeyStore(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []KV{{1, "one"}, {2, "two"}}
+
+	assert.Equal(slices.KeyStore(values, key, 2, KV{2, "zwei"}), []KV{{1, "one"}, {2, "zwei"}})
+	assert.Equal(slices.KeyStore(values, key, 3, KV{3, "three"}), []KV{{1, "one"}, {2, "two"}, {3, "three"}})
+	assert.Equal(slices.KeyStore(nil, key, 1, KV{1, "one"}), []KV{{1, "one"}})
+}
+
+// TestKeyTake verifies the taking of a value by key.
+func TestKeyTake(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []KV{{1, "one"}, {2, "two"}, {3, "three"}}
+
+	v, rest, ok := slices.KeyTake(values, key, 2)
+	assert.True(ok)
+	assert.Equal(v, KV{2, "two"})
+	assert.Equal(rest, []KV{{1, "one"}, {3, "three"}})
+
+	v, rest, ok = slices.KeyTake(values, key, 4)
+	assert.False(ok)
+	assert.Equal(v, KV{})
+	assert.Equal(rest, values)
+
+	_, rest, ok = slices.KeyTake(nil, key, 1)
+	assert.False(ok)
+	assert.Nil(rest)
+}
+
+// EOF
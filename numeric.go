@@ -0,0 +1,203 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+)
+
+//--------------------
+// ELEMENT-WISE
+//--------------------
+
+// Add returns the sums of the values at the same positions of both
+// slices. Slices of unequal length return ErrLengthMismatch.
+func Add[V Number](a, b []V) ([]V, error) {
+	return elementWise(a, b, func(va, vb V) V { return va + vb })
+}
+
+// Div returns the quotients of the values at the same positions of both
+// slices. Slices of unequal length return ErrLengthMismatch. A division
+// by zero behaves like the Go operator, so it panics for integer types.
+func Div[V Number](a, b []V) ([]V, error) {
+	return elementWise(a, b, func(va, vb V) V { return va / vb })
+}
+
+// Mul returns the products of the values at the same positions of both
+// slices. Slices of unequal length return ErrLengthMismatch.
+func Mul[V Number](a, b []V) ([]V, error) {
+	return elementWise(a, b, func(va, vb V) V { return va * vb })
+}
+
+// Sub returns the differences of the values at the same positions of both
+// slices. Slices of unequal length return ErrLengthMismatch.
+func Sub[V Number](a, b []V) ([]V, error) {
+	return elementWise(a, b, func(va, vb V) V { return va - vb })
+}
+
+//--------------------
+// VECTOR
+//--------------------
+
+// Clamp returns a copy of the slice with all values limited to the range
+// from lo to hi.
+func Clamp[V Number](ivs []V, lo, hi V) []V {
+	if ivs == nil {
+		return nil
+	}
+	ovs := make([]V, len(ivs))
+	for i, v := range ivs {
+		switch {
+		case v < lo:
+			ovs[i] = lo
+		case v > hi:
+			ovs[i] = hi
+		default:
+			ovs[i] = v
+		}
+	}
+	return ovs
+}
+
+// CumProd returns the cumulative products of the values, so each value
+// is the product of all values up to its position.
+func CumProd[V Number](ivs []V) []V {
+	return cumulate(ivs, func(v, acc V) V { return acc * v })
+}
+
+// CumSum returns the cumulative sums of the values, so each value is the
+// sum of all values up to its position.
+func CumSum[V Number](ivs []V) []V {
+	return cumulate(ivs, func(v, acc V) V { return acc + v })
+}
+
+// Differences returns the discrete differences between each value and its
+// predecessor. The result is one value shorter than the slice.
+func Differences[V Number](ivs []V) []V {
+	if ivs == nil {
+		return nil
+	}
+	if len(ivs) < 2 {
+		return []V{}
+	}
+	ovs := make([]V, len(ivs)-1)
+	for i := range ovs {
+		ovs[i] = ivs[i+1] - ivs[i]
+	}
+	return ovs
+}
+
+// Dot returns the dot product of both slices. Slices of unequal length
+// return ErrLengthMismatch.
+func Dot[V Number](a, b []V) (V, error) {
+	if len(a) != len(b) {
+		return 0, ErrLengthMismatch
+	}
+	var dot V
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot, nil
+}
+
+// Norm returns the Euclidean length of the slice as a vector. Like
+// math.Hypot() the values are scaled by the largest absolute value to
+// avoid overflow and underflow. An infinite value returns +Inf, otherwise
+// a NaN value returns NaN.
+func Norm[V Number](ivs []V) float64 {
+	scale := 0.0
+	hasNaN := false
+	for _, v := range ivs {
+		f := math.Abs(float64(v))
+		switch {
+		case math.IsInf(f, 1):
+			return f
+		case math.IsNaN(f):
+			hasNaN = true
+		case f > scale:
+			scale = f
+		}
+	}
+	if hasNaN {
+		return math.NaN()
+	}
+	if scale == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range ivs {
+		f := float64(v) / scale
+		sum += f * f
+	}
+	return scale * math.Sqrt(sum)
+}
+
+// Normalize returns the slice scaled to a Euclidean length of one. A slice
+// containing only zeros stays unchanged.
+func Normalize[V Number](ivs []V) []float64 {
+	if ivs == nil {
+		return nil
+	}
+	norm := Norm(ivs)
+	return Map(ivs, func(v V) float64 {
+		if norm == 0 {
+			return 0
+		}
+		return float64(v) / norm
+	})
+}
+
+// Scale returns a copy of the slice with all values multiplied by
+// the factor.
+func Scale[V Number](ivs []V, factor V) []V {
+	return Map(ivs, func(v V) V {
+		return v * factor
+	})
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// elementWise combines the values at the same positions of both slices
+// with the operator function.
+func elementWise[V Number](a, b []V, op func(V, V) V) ([]V, error) {
+	if len(a) != len(b) {
+		return nil, ErrLengthMismatch
+	}
+	if a == nil && b == nil {
+		return nil, nil
+	}
+	ovs := make([]V, len(a))
+	for i := range a {
+		ovs[i] = op(a[i], b[i])
+	}
+	return ovs, nil
+}
+
+// cumulate returns the accumulated values after each step.
+func cumulate[V Number](ivs []V, fun func(V, V) V) []V {
+	if ivs == nil {
+		return nil
+	}
+	ovs := make([]V, len(ivs))
+	for i, v := range ivs {
+		if i == 0 {
+			ovs[i] = v
+			continue
+		}
+		ovs[i] = fun(v, ovs[i-1])
+	}
+	return ovs
+}
+
+// EOF
@@ -0,0 +1,192 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestElementWise verifies the element-wise arithmetic of two slices.
+func TestElementWise(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	a := []int{6, 8, 10}
+	b := []int{3, 2, 5}
+	tests := []struct {
+		descr string
+		op    func(a, b []int) ([]int, error)
+		out   []int
+	}{
+		{"Add", slices.Add[int], []int{9, 10, 15}},
+		{"Sub", slices.Sub[int], []int{3, 6, 5}},
+		{"Mul", slices.Mul[int], []int{18, 16, 50}},
+		{"Div", slices.Div[int], []int{2, 4, 2}},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		vs, err := test.op(a, b)
+		assert.NoError(err)
+		assert.Equal(vs, test.out)
+		_, err = test.op(a, b[:2])
+		assert.ErrorMatch(err, ".*length mismatch.*")
+		vs, err = test.op([]int{}, []int{})
+		assert.NoError(err)
+		assert.Equal(vs, []int{})
+		vs, err = test.op(nil, nil)
+		assert.NoError(err)
+		assert.Nil(vs)
+	}
+
+	assert.Panics(func() { _, _ = slices.Div([]int{1}, []int{0}) })
+}
+
+// TestClamp verifies the limiting of values.
+func TestClamp(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Clamp([]int{-5, 0, 5, 10, 15}, 0, 10), []int{0, 0, 5, 10, 10})
+	assert.Equal(slices.Clamp([]float64{-0.5, 0.5, 1.5}, 0, 1), []float64{0, 0.5, 1})
+	assert.Nil(slices.Clamp(nil, 0, 10))
+}
+
+// TestCumulative verifies the cumulative sums and products.
+func TestCumulative(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.CumSum([]int{1, 2, 3, 4}), []int{1, 3, 6, 10})
+	assert.Equal(slices.CumProd([]int{1, 2, 3, 4}), []int{1, 2, 6, 24})
+	assert.Equal(slices.CumSum([]int{}), []int{})
+	assert.Nil(slices.CumProd[int](nil))
+}
+
+// TestDifferences verifies the discrete differences.
+func TestDifferences(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Differences([]int{1, 4, 9, 16}), []int{3, 5, 7})
+	assert.Equal(slices.Differences([]int{1}), []int{})
+	assert.Nil(slices.Differences[int](nil))
+
+	// Differences is the inverse of CumSum.
+	values := []int{3, 1, 4, 1, 5}
+	assert.Equal(slices.Differences(slices.CumSum(values)), values[1:])
+}
+
+// TestDotNorm verifies the dot product and the Euclidean norm.
+func TestDotNorm(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	dot, err := slices.Dot([]int{1, 2, 3}, []int{4, 5, 6})
+	assert.NoError(err)
+	assert.Equal(dot, 32)
+	_, err = slices.Dot([]int{1, 2, 3}, []int{4, 5})
+	assert.ErrorMatch(err, ".*length mismatch.*")
+
+	assert.Equal(slices.Norm([]int{3, 4}), 5.0)
+	assert.Equal(slices.Norm[int](nil), 0.0)
+	assert.About(slices.Norm([]float64{1e200, 1e200}), math.Sqrt2*1e200, 1e186)
+	assert.About(slices.Norm([]float64{3e-200, 4e-200}), 5e-200, 1e-214)
+	assert.True(math.IsInf(slices.Norm([]float64{1, math.NaN(), math.Inf(-1)}), 1))
+	assert.True(math.IsNaN(slices.Norm([]float64{1, math.NaN()})))
+}
+
+// TestNormalize verifies the scaling to unit length.
+func TestNormalize(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Normalize([]int{3, 4}), []float64{0.6, 0.8})
+	assert.Equal(slices.Normalize([]int{0, 0}), []float64{0, 0})
+	unit := slices.Normalize([]float64{1e200, 1e200})
+	assert.Length(unit, 2)
+	assert.About(unit[0], 1/math.Sqrt2, 1e-15)
+	assert.About(unit[1], 1/math.Sqrt2, 1e-15)
+	assert.Nil(slices.Normalize[int](nil))
+}
+
+// TestScale verifies the multiplying with a factor.
+func TestScale(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Scale([]int{1, 2, 3}, 3), []int{3, 6, 9})
+	assert.Equal(slices.Scale([]float64{1, 2}, 0.5), []float64{0.5, 1})
+	assert.Nil(slices.Scale(nil, 3))
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkAdd runs a performance test on element-wise adding.
+func BenchmarkAdd(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vsa := gen.Ints(0, 1000, 10000)
+	vsb := gen.Ints(0, 1000, 10000)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = slices.Add(vsa, vsb)
+	}
+}
+
+// BenchmarkAddLoop runs a performance test on a hand-written adding loop
+// as reference for BenchmarkAdd.
+func BenchmarkAddLoop(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vsa := gen.Ints(0, 1000, 10000)
+	vsb := gen.Ints(0, 1000, 10000)
+
+	for i := 0; i < b.N; i++ {
+		vs := make([]int, len(vsa))
+		for j := range vsa {
+			vs[j] = vsa[j] + vsb[j]
+		}
+	}
+}
+
+// BenchmarkDot runs a performance test on the dot product.
+func BenchmarkDot(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vsa := gen.Ints(0, 1000, 10000)
+	vsb := gen.Ints(0, 1000, 10000)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = slices.Dot(vsa, vsb)
+	}
+}
+
+// BenchmarkDotLoop runs a performance test on a hand-written dot product
+// loop as reference for BenchmarkDot.
+func BenchmarkDotLoop(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vsa := gen.Ints(0, 1000, 10000)
+	vsb := gen.Ints(0, 1000, 10000)
+
+	for i := 0; i < b.N; i++ {
+		dot := 0
+		for j := range vsa {
+			dot += vsa[j] * vsb[j]
+		}
+		_ = dot
+	}
+}
+
+// EOF
@@ -0,0 +1,131 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// Page contains the values of one page of a slice together with the
+// information needed for navigation.
+type Page[V any] struct {
+	Items   []V
+	Number  int
+	Size    int
+	Total   int
+	Pages   int
+	HasNext bool
+	HasPrev bool
+}
+
+// CursorPage contains the values of one page of a slice sorted by a key.
+// Next is the cursor for the following page, it is nil on the last page.
+type CursorPage[V any, K constraints.Ordered] struct {
+	Items   []V
+	Next    *K
+	HasNext bool
+}
+
+//--------------------
+// PAGINATION
+//--------------------
+
+// Limit returns up to limit values starting at offset. The clamping is
+// the same as for Subslice(), so a negative offset shortens the result and
+// an offset behind the slice as well as a limit below one return nil.
+func Limit[V any](ivs []V, offset, limit int) []V {
+	if limit < 1 {
+		return nil
+	}
+	tpos := offset + limit - 1
+	if tpos < offset {
+		// Overflow, so take all values starting at offset.
+		tpos = len(ivs) - 1
+	}
+	return Subslice(ivs, offset, tpos)
+}
+
+// Paginate returns the page with the given number. Pages are numbered
+// starting at one, a lower number is set to one. A page behind the last
+// one has no items, a size below one returns a page without items and
+// navigation.
+func Paginate[V any](ivs []V, number, size int) Page[V] {
+	if number < 1 {
+		number = 1
+	}
+	page := Page[V]{
+		Number: number,
+		Size:   size,
+		Total:  len(ivs),
+	}
+	if size < 1 {
+		return page
+	}
+	page.Pages = len(ivs) / size
+	if len(ivs)%size != 0 {
+		page.Pages++
+	}
+	page.HasPrev = number > 1
+	page.HasNext = number < page.Pages
+	if number <= page.Pages {
+		page.Items = Limit(ivs, (number-1)*size, size)
+	}
+	return page
+}
+
+// PaginateCursor returns up to size values of a slice sorted ascending by
+// the key function. The page starts behind the value with the cursor key,
+// a nil cursor starts at the beginning. The Next field of the returned page
+// is the cursor for the next call. As values are found by their key and not
+// by their position the iteration stays stable even if values are inserted
+// or deleted in between. Values sharing the key of the last value of a page
+// are kept on the same page, so it may contain more than size values.
+func PaginateCursor[V any, K constraints.Ordered](ivs []V, key func(V) K, cursor *K, size int) CursorPage[V, K] {
+	if ivs == nil || size < 1 {
+		return CursorPage[V, K]{}
+	}
+	fpos := 0
+	if cursor != nil {
+		lo, hi := 0, len(ivs)
+		for lo < hi {
+			mid := int(uint(lo+hi) >> 1)
+			if key(ivs[mid]) <= *cursor {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		fpos = lo
+	}
+	tpos := fpos + size
+	if tpos > len(ivs) || tpos < fpos {
+		tpos = len(ivs)
+	}
+	for tpos > fpos && tpos < len(ivs) && key(ivs[tpos]) == key(ivs[tpos-1]) {
+		tpos++
+	}
+	page := CursorPage[V, K]{
+		Items: Copy(ivs[fpos:tpos]),
+	}
+	if tpos < len(ivs) {
+		next := key(ivs[tpos-1])
+		page.Next = &next
+		page.HasNext = true
+	}
+	return page
+}
+
+// EOF
@@ -0,0 +1,167 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestLimit verifies the offset and limit access to slices.
+func TestLimit(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		offset int
+		limit  int
+		out    []int
+	}{
+		{
+			descr:  "Inside of slice",
+			values: []int{1, 2, 3, 4, 5},
+			offset: 1,
+			limit:  3,
+			out:    []int{2, 3, 4},
+		}, {
+			descr:  "Limit reaching behind slice",
+			values: []int{1, 2, 3, 4, 5},
+			offset: 3,
+			limit:  10,
+			out:    []int{4, 5},
+		}, {
+			descr:  "Negative offset",
+			values: []int{1, 2, 3, 4, 5},
+			offset: -1,
+			limit:  3,
+			out:    []int{1, 2},
+		}, {
+			descr:  "Maximum limit",
+			values: []int{1, 2, 3, 4, 5},
+			offset: 2,
+			limit:  math.MaxInt,
+			out:    []int{3, 4, 5},
+		}, {
+			descr:  "Offset behind slice",
+			values: []int{1, 2, 3, 4, 5},
+			offset: 5,
+			limit:  3,
+			out:    nil,
+		}, {
+			descr:  "Zero limit",
+			values: []int{1, 2, 3, 4, 5},
+			offset: 0,
+			limit:  0,
+			out:    nil,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			offset: 0,
+			limit:  3,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Limit(test.values, test.offset, test.limit), test.out)
+	}
+}
+
+// TestPaginate verifies the paging of slices.
+func TestPaginate(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ivs := []int{1, 2, 3, 4, 5, 6, 7}
+
+	assert.Equal(slices.Paginate(ivs, 1, 3), slices.Page[int]{
+		Items: []int{1, 2, 3}, Number: 1, Size: 3, Total: 7, Pages: 3, HasNext: true, HasPrev: false,
+	})
+	assert.Equal(slices.Paginate(ivs, 2, 3), slices.Page[int]{
+		Items: []int{4, 5, 6}, Number: 2, Size: 3, Total: 7, Pages: 3, HasNext: true, HasPrev: true,
+	})
+	assert.Equal(slices.Paginate(ivs, 3, 3), slices.Page[int]{
+		Items: []int{7}, Number: 3, Size: 3, Total: 7, Pages: 3, HasNext: false, HasPrev: true,
+	})
+	assert.Equal(slices.Paginate(ivs, 4, 3), slices.Page[int]{
+		Items: nil, Number: 4, Size: 3, Total: 7, Pages: 3, HasNext: false, HasPrev: true,
+	})
+	assert.Equal(slices.Paginate(ivs, 0, 7), slices.Page[int]{
+		Items: ivs, Number: 1, Size: 7, Total: 7, Pages: 1, HasNext: false, HasPrev: false,
+	})
+	assert.Equal(slices.Paginate(ivs, 1, math.MaxInt), slices.Page[int]{
+		Items: ivs, Number: 1, Size: math.MaxInt, Total: 7, Pages: 1, HasNext: false, HasPrev: false,
+	})
+	assert.Equal(slices.Paginate(ivs, 2, math.MaxInt), slices.Page[int]{
+		Items: nil, Number: 2, Size: math.MaxInt, Total: 7, Pages: 1, HasNext: false, HasPrev: true,
+	})
+	assert.Equal(slices.Paginate(ivs, 1, 0), slices.Page[int]{
+		Number: 1, Size: 0, Total: 7,
+	})
+	assert.Equal(slices.Paginate[int](nil, 1, 3), slices.Page[int]{
+		Number: 1, Size: 3,
+	})
+
+	// All pages together contain all values.
+	var all []int
+	for n := 1; ; n++ {
+		page := slices.Paginate(ivs, n, 2)
+		all = append(all, page.Items...)
+		if !page.HasNext {
+			break
+		}
+	}
+	assert.Equal(all, ivs)
+}
+
+// TestPaginateCursor verifies the paging of sorted slices by cursor keys.
+func TestPaginateCursor(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ivs := []int{1, 3, 4, 4, 4, 6, 8, 9}
+	same := func(v int) int { return v }
+
+	page := slices.PaginateCursor(ivs, same, nil, 3)
+	assert.Equal(page.Items, []int{1, 3, 4, 4, 4})
+	assert.True(page.HasNext)
+	assert.Equal(*page.Next, 4)
+
+	page = slices.PaginateCursor(ivs, same, page.Next, 3)
+	assert.Equal(page.Items, []int{6, 8, 9})
+	assert.False(page.HasNext)
+	assert.Nil(page.Next)
+
+	// A cursor stays valid after changes of the slice.
+	cursor := 3
+	page = slices.PaginateCursor([]int{1, 2, 5, 6, 7}, same, &cursor, 2)
+	assert.Equal(page.Items, []int{5, 6})
+	assert.Equal(*page.Next, 6)
+
+	cursor = 9
+	page = slices.PaginateCursor(ivs, same, &cursor, 3)
+	assert.Equal(page.Items, []int{})
+	assert.False(page.HasNext)
+
+	assert.Equal(slices.PaginateCursor(ivs, same, nil, 0), slices.CursorPage[int, int]{})
+	assert.Equal(slices.PaginateCursor[int, int](nil, same, nil, 3), slices.CursorPage[int, int]{})
+}
+
+// EOF
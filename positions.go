@@ -0,0 +1,138 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// POSITIONS
+//--------------------
+
+// InsertAt returns a copy of the slice with the value v inserted at the
+// position pos. Negative positions as well as too high ones are allowed
+// and will be limited.
+func InsertAt[V any](ivs []V, pos int, v V) []V {
+	return Splice(ivs, pos, 0, v)
+}
+
+// InsertAllAt returns a copy of the slice with the values of the second
+// slice inserted at the position pos. Negative positions as well as too
+// high ones are allowed and will be limited.
+func InsertAllAt[V any](ivs []V, pos int, vs []V) []V {
+	return Splice(ivs, pos, 0, vs...)
+}
+
+// Move returns a copy of the slice where the value at position from is
+// moved to position to. Positions out of range leave the copy unchanged.
+func Move[V any](ivs []V, from, to int) []V {
+	if !inRange(from, len(ivs)) || !inRange(to, len(ivs)) {
+		return Copy(ivs)
+	}
+	v := ivs[from]
+	return InsertAt(Splice(ivs, from, 1), to, v)
+}
+
+// ReplaceAll returns a copy of the slice where all values equal to ov are
+// replaced by nv.
+func ReplaceAll[V comparable](ivs []V, ov, nv V) []V {
+	return ReplaceWith(ivs, func(v V) bool {
+		return v == ov
+	}, func(V) V {
+		return nv
+	})
+}
+
+// ReplaceAt returns a copy of the slice where the value at position pos is
+// replaced by v. Positions out of range leave the copy unchanged.
+func ReplaceAt[V any](ivs []V, pos int, v V) []V {
+	ovs := Copy(ivs)
+	if inRange(pos, len(ovs)) {
+		ovs[pos] = v
+	}
+	return ovs
+}
+
+// ReplaceWith returns a copy of the slice where all values for which pred()
+// returns true are replaced by the result of fun().
+func ReplaceWith[V any](ivs []V, pred func(V) bool, fun func(V) V) []V {
+	return Map(ivs, func(v V) V {
+		if pred(v) {
+			return fun(v)
+		}
+		return v
+	})
+}
+
+// RotateLeft returns a copy of the slice with all values moved n positions
+// to the left. Values leaving the slice at the start are appended at the end.
+// A negative n rotates to the right.
+func RotateLeft[V any](ivs []V, n int) []V {
+	if len(ivs) == 0 {
+		return Copy(ivs)
+	}
+	n %= len(ivs)
+	if n < 0 {
+		n += len(ivs)
+	}
+	ovs := make([]V, 0, len(ivs))
+	ovs = append(ovs, ivs[n:]...)
+	return append(ovs, ivs[:n]...)
+}
+
+// RotateRight returns a copy of the slice with all values moved n positions
+// to the right. Values leaving the slice at the end are prepended at the
+// start. A negative n rotates to the left.
+func RotateRight[V any](ivs []V, n int) []V {
+	return RotateLeft(ivs, -n)
+}
+
+// Splice returns a copy of the slice where deleteCount values starting at
+// position pos are removed and the inserted values are put in at the same
+// position. Negative positions as well as too high ones and counts are
+// allowed and will be limited.
+func Splice[V any](ivs []V, pos, deleteCount int, inserts ...V) []V {
+	if ivs == nil && len(inserts) == 0 {
+		return nil
+	}
+	pos = between(pos, 0, len(ivs))
+	deleteCount = between(deleteCount, 0, len(ivs)-pos)
+	ovs := make([]V, 0, len(ivs)-deleteCount+len(inserts))
+	ovs = append(ovs, ivs[:pos]...)
+	ovs = append(ovs, inserts...)
+	return append(ovs, ivs[pos+deleteCount:]...)
+}
+
+// Swap returns a copy of the slice where the values at the positions
+// i and j are exchanged. Positions out of range leave the copy unchanged.
+func Swap[V any](ivs []V, i, j int) []V {
+	ovs := Copy(ivs)
+	if inRange(i, len(ovs)) && inRange(j, len(ovs)) {
+		swap(ovs, i, j)
+	}
+	return ovs
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// inRange returns true if pos is a valid position in a slice of length n.
+func inRange(pos, n int) bool {
+	return pos >= 0 && pos < n
+}
+
+// between returns the value limited to the range from lo to hi.
+func between(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// EOF
@@ -0,0 +1,269 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestInsertAt verifies the inserting of a value at a position.
+func TestInsertAt(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		pos    int
+		out    []int
+	}{
+		{
+			descr:  "Insert in the middle",
+			values: []int{1, 2, 3},
+			pos:    1,
+			out:    []int{1, 0, 2, 3},
+		}, {
+			descr:  "Insert at start",
+			values: []int{1, 2, 3},
+			pos:    0,
+			out:    []int{0, 1, 2, 3},
+		}, {
+			descr:  "Insert at end",
+			values: []int{1, 2, 3},
+			pos:    3,
+			out:    []int{1, 2, 3, 0},
+		}, {
+			descr:  "Negative position",
+			values: []int{1, 2, 3},
+			pos:    -5,
+			out:    []int{0, 1, 2, 3},
+		}, {
+			descr:  "Too high position",
+			values: []int{1, 2, 3},
+			pos:    10,
+			out:    []int{1, 2, 3, 0},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			pos:    0,
+			out:    []int{0},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.InsertAt(test.values, test.pos, 0), test.out)
+	}
+}
+
+// TestInsertAllAt verifies the inserting of multiple values at a position.
+func TestInsertAllAt(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{1, 2, 3}
+
+	assert.Equal(slices.InsertAllAt(values, 1, []int{8, 9}), []int{1, 8, 9, 2, 3})
+	assert.Equal(slices.InsertAllAt(values, 1, nil), []int{1, 2, 3})
+	assert.Equal(values, []int{1, 2, 3})
+	assert.Nil(slices.InsertAllAt(nil, 1, []int{}))
+}
+
+// TestMove verifies the moving of a value.
+func TestMove(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{1, 2, 3, 4, 5}
+
+	assert.Equal(slices.Move(values, 0, 3), []int{2, 3, 4, 1, 5})
+	assert.Equal(slices.Move(values, 4, 1), []int{1, 5, 2, 3, 4})
+	assert.Equal(slices.Move(values, 2, 2), []int{1, 2, 3, 4, 5})
+	assert.Equal(slices.Move(values, -1, 2), []int{1, 2, 3, 4, 5})
+	assert.Equal(slices.Move(values, 5, 0), []int{1, 2, 3, 4, 5})
+	assert.Equal(slices.Move(values, 0, 5), []int{1, 2, 3, 4, 5})
+	assert.Equal(slices.Move(values, 2, -1), []int{1, 2, 3, 4, 5})
+	assert.Equal(values, []int{1, 2, 3, 4, 5})
+	assert.Equal(slices.Move([]int{}, 0, 1), []int{})
+	assert.Nil(slices.Move[int](nil, 0, 1))
+}
+
+// TestReplaceAll verifies the replacing of all equal values.
+func TestReplaceAll(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.ReplaceAll([]int{1, 2, 1, 3}, 1, 9), []int{9, 2, 9, 3})
+	assert.Equal(slices.ReplaceAll([]int{1, 2, 3}, 4, 9), []int{1, 2, 3})
+	assert.Nil(slices.ReplaceAll(nil, 1, 9))
+}
+
+// TestReplaceAt verifies the replacing of a value at a position.
+func TestReplaceAt(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.ReplaceAt([]int{1, 2, 3}, 1, 9), []int{1, 9, 3})
+	assert.Equal(slices.ReplaceAt([]int{1, 2, 3}, -1, 9), []int{1, 2, 3})
+	assert.Equal(slices.ReplaceAt([]int{1, 2, 3}, 3, 9), []int{1, 2, 3})
+	assert.Nil(slices.ReplaceAt(nil, 0, 9))
+}
+
+// TestReplaceWith verifies the replacing of values by predicate.
+func TestReplaceWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	even := func(v int) bool { return v%2 == 0 }
+	negate := func(v int) int { return -v }
+
+	assert.Equal(slices.ReplaceWith([]int{1, 2, 3, 4}, even, negate), []int{1, -2, 3, -4})
+	assert.Equal(slices.ReplaceWith([]int{}, even, negate), []int{})
+	assert.Nil(slices.ReplaceWith(nil, even, negate))
+}
+
+// TestRotate verifies the rotating of slices.
+func TestRotate(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		n      int
+		left   []int
+		right  []int
+	}{
+		{
+			descr:  "Rotate by one",
+			values: []int{1, 2, 3, 4},
+			n:      1,
+			left:   []int{2, 3, 4, 1},
+			right:  []int{4, 1, 2, 3},
+		}, {
+			descr:  "Rotate by more than length",
+			values: []int{1, 2, 3, 4},
+			n:      6,
+			left:   []int{3, 4, 1, 2},
+			right:  []int{3, 4, 1, 2},
+		}, {
+			descr:  "Rotate negative",
+			values: []int{1, 2, 3, 4},
+			n:      -1,
+			left:   []int{4, 1, 2, 3},
+			right:  []int{2, 3, 4, 1},
+		}, {
+			descr:  "Rotate by zero",
+			values: []int{1, 2, 3},
+			n:      0,
+			left:   []int{1, 2, 3},
+			right:  []int{1, 2, 3},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			n:      1,
+			left:   []int{},
+			right:  []int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			n:      1,
+			left:   nil,
+			right:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.RotateLeft(test.values, test.n), test.left)
+		assert.Equal(slices.RotateRight(test.values, test.n), test.right)
+	}
+}
+
+// TestSplice verifies the deleting and inserting of values at a position.
+func TestSplice(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr       string
+		values      []int
+		pos         int
+		deleteCount int
+		inserts     []int
+		out         []int
+	}{
+		{
+			descr:       "Delete and insert",
+			values:      []int{1, 2, 3, 4, 5},
+			pos:         1,
+			deleteCount: 2,
+			inserts:     []int{8, 9, 10},
+			out:         []int{1, 8, 9, 10, 4, 5},
+		}, {
+			descr:       "Delete only",
+			values:      []int{1, 2, 3, 4, 5},
+			pos:         3,
+			deleteCount: 1,
+			out:         []int{1, 2, 3, 5},
+		}, {
+			descr:       "Too high delete count",
+			values:      []int{1, 2, 3, 4, 5},
+			pos:         3,
+			deleteCount: 10,
+			inserts:     []int{9},
+			out:         []int{1, 2, 3, 9},
+		}, {
+			descr:       "Negative delete count",
+			values:      []int{1, 2, 3},
+			pos:         1,
+			deleteCount: -1,
+			inserts:     []int{9},
+			out:         []int{1, 9, 2, 3},
+		}, {
+			descr:       "Delete all",
+			values:      []int{1, 2, 3},
+			pos:         0,
+			deleteCount: 3,
+			out:         []int{},
+		}, {
+			descr:       "Nil slice",
+			values:      nil,
+			pos:         0,
+			deleteCount: 1,
+			out:         nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Splice(test.values, test.pos, test.deleteCount, test.inserts...), test.out)
+	}
+}
+
+// TestSwap verifies the exchanging of two values.
+func TestSwap(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{1, 2, 3, 4}
+
+	assert.Equal(slices.Swap(values, 0, 3), []int{4, 2, 3, 1})
+	assert.Equal(slices.Swap(values, 1, 1), []int{1, 2, 3, 4})
+	assert.Equal(slices.Swap(values, -1, 2), []int{1, 2, 3, 4})
+	assert.Equal(slices.Swap(values, 0, 4), []int{1, 2, 3, 4})
+	assert.Equal(slices.Swap(values, 10, 1), []int{1, 2, 3, 4})
+	assert.Equal(values, []int{1, 2, 3, 4})
+	assert.Equal(slices.Swap([]int{}, 0, 1), []int{})
+	assert.Nil(slices.Swap[int](nil, 0, 1))
+}
+
+// EOF
@@ -0,0 +1,276 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// QUERY
+//--------------------
+
+// queryOrder is one criterion of an ordering stage.
+type queryOrder[V any] struct {
+	less       func(a, b V) bool
+	descending bool
+}
+
+// Query is a declarative query over a slice. The builder methods return
+// a new query and leave the receiver untouched, so queries can be shared
+// and extended. Nothing is evaluated before a terminal method like ToSlice()
+// or Count() is called. Each stage is done by the matching slice function,
+// e.g. Where() by Filter() or OrderBy() by SortWith().
+//
+// Type changing stages like Select() and GroupBy() are functions, as Go
+// methods cannot have own type parameters.
+type Query[V any] struct {
+	source func() []V
+	stages []func([]V) []V
+	orders []queryOrder[V]
+}
+
+// NewQuery creates a query over the values of the slice.
+func NewQuery[V any](ivs []V) *Query[V] {
+	return &Query[V]{
+		source: func() []V { return ivs },
+	}
+}
+
+// Where keeps only the values where pred() returns true.
+func (q *Query[V]) Where(pred func(V) bool) *Query[V] {
+	return q.with(func(vs []V) []V {
+		return Filter(vs, pred)
+	})
+}
+
+// OrderBy sorts the values using less as primary criterion. Values with
+// equal order keep their previous order. Use By() to create the less
+// function from a key.
+func (q *Query[V]) OrderBy(less func(a, b V) bool) *Query[V] {
+	nq := q.flushed()
+	nq.orders = []queryOrder[V]{{less: less}}
+	return nq
+}
+
+// ThenBy adds a further criterion to the preceding OrderBy() for values
+// which are equal so far. Without a preceding OrderBy() it acts like it.
+func (q *Query[V]) ThenBy(less func(a, b V) bool) *Query[V] {
+	nq := q.clone()
+	nq.orders = append(nq.orders, queryOrder[V]{less: less})
+	return nq
+}
+
+// Descending reverses the direction of the last OrderBy() or ThenBy()
+// criterion. Without any criterion the query is returned unchanged.
+func (q *Query[V]) Descending() *Query[V] {
+	nq := q.clone()
+	if len(nq.orders) > 0 {
+		last := len(nq.orders) - 1
+		nq.orders[last].descending = !nq.orders[last].descending
+	}
+	return nq
+}
+
+// Skip drops the first n values.
+func (q *Query[V]) Skip(n int) *Query[V] {
+	return q.with(func(vs []V) []V {
+		if n <= 0 {
+			return vs
+		}
+		return nonNil(vs, Subslice(vs, n, len(vs)-1))
+	})
+}
+
+// Take keeps only the first n values.
+func (q *Query[V]) Take(n int) *Query[V] {
+	return q.with(func(vs []V) []V {
+		return nonNil(vs, Subslice(vs, 0, n-1))
+	})
+}
+
+// ToSlice evaluates the query and returns the resulting values. A query
+// over a nil slice returns nil.
+func (q *Query[V]) ToSlice() []V {
+	nq := q.flushed()
+	vs := nq.source()
+	for _, stage := range nq.stages {
+		vs = stage(vs)
+	}
+	return vs
+}
+
+// Count evaluates the query and returns the number of resulting values.
+func (q *Query[V]) Count() int {
+	return len(q.ToSlice())
+}
+
+// Any evaluates the query and returns true if pred() returns true for
+// at least one resulting value.
+func (q *Query[V]) Any(pred func(V) bool) bool {
+	return ContainsAny(q.ToSlice(), pred)
+}
+
+// All evaluates the query and returns true if pred() returns true for
+// all resulting values.
+func (q *Query[V]) All(pred func(V) bool) bool {
+	return ContainsAll(q.ToSlice(), pred)
+}
+
+// clone returns a copy of the query which can be changed independently.
+func (q *Query[V]) clone() *Query[V] {
+	return &Query[V]{
+		source: q.source,
+		stages: Copy(q.stages),
+		orders: Copy(q.orders),
+	}
+}
+
+// flushed returns a copy of the query with pending orders turned into
+// a sorting stage.
+func (q *Query[V]) flushed() *Query[V] {
+	nq := q.clone()
+	if len(nq.orders) == 0 {
+		return nq
+	}
+	orders := nq.orders
+	nq.orders = nil
+	nq.stages = append(nq.stages, func(vs []V) []V {
+		return orderValues(vs, orders)
+	})
+	return nq
+}
+
+// with returns a copy of the query with the stage appended.
+func (q *Query[V]) with(stage func([]V) []V) *Query[V] {
+	nq := q.flushed()
+	nq.stages = append(nq.stages, stage)
+	return nq
+}
+
+//--------------------
+// QUERY FUNCTIONS
+//--------------------
+
+// Group contains the values of a query sharing the same key.
+type Group[K comparable, V any] struct {
+	Key    K
+	Values []V
+}
+
+// By creates a less function for OrderBy() and ThenBy() comparing the
+// keys returned by the key function.
+func By[V any, K constraints.Ordered](key func(V) K) func(a, b V) bool {
+	return func(a, b V) bool {
+		return key(a) < key(b)
+	}
+}
+
+// Select converts the values of the query with fun().
+func Select[I, O any](q *Query[I], fun func(I) O) *Query[O] {
+	return &Query[O]{
+		source: func() []O { return Map(q.ToSlice(), fun) },
+	}
+}
+
+// Distinct keeps only the first value for each key returned by the key
+// function.
+func Distinct[V any, K comparable](q *Query[V], key func(V) K) *Query[V] {
+	return q.with(func(vs []V) []V {
+		return UniqueWith(vs, key)
+	})
+}
+
+// GroupBy groups the values of the query by the key function. The groups
+// are ordered by the first appearance of their key, the values inside a
+// group keep their order.
+func GroupBy[V any, K comparable](q *Query[V], key func(V) K) *Query[Group[K, V]] {
+	return &Query[Group[K, V]]{
+		source: func() []Group[K, V] {
+			vs := q.ToSlice()
+			if vs == nil {
+				return nil
+			}
+			gs := []Group[K, V]{}
+			positions := map[K]int{}
+			for _, v := range vs {
+				k := key(v)
+				pos, ok := positions[k]
+				if !ok {
+					pos = len(gs)
+					positions[k] = pos
+					gs = append(gs, Group[K, V]{Key: k})
+				}
+				gs[pos].Values = append(gs[pos].Values, v)
+			}
+			return gs
+		},
+	}
+}
+
+// Having keeps only the groups where pred() returns true. It is Where()
+// for grouped queries.
+func Having[K comparable, V any](q *Query[Group[K, V]], pred func(Group[K, V]) bool) *Query[Group[K, V]] {
+	return q.Where(pred)
+}
+
+// Aggregate reduces the values of each group with agg(), e.g. Sum() or
+// Mean(), and pairs the result with the group key.
+func Aggregate[K comparable, V, O any](q *Query[Group[K, V]], agg func([]V) O) *Query[Pair[K, O]] {
+	return Select(q, func(g Group[K, V]) Pair[K, O] {
+		return Pair[K, O]{g.Key, agg(g.Values)}
+	})
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// orderValues sorts the values by the orders. The original position
+// is the final criterion to keep the sorting stable.
+func orderValues[V any](vs []V, orders []queryOrder[V]) []V {
+	if vs == nil {
+		return nil
+	}
+	pvs := make([]Pair[V, int], len(vs))
+	for i, v := range vs {
+		pvs[i] = Pair[V, int]{v, i}
+	}
+	less := func(pvs []Pair[V, int], i, j int) bool {
+		for _, order := range orders {
+			a, b := pvs[i].First, pvs[j].First
+			if order.descending {
+				a, b = b, a
+			}
+			switch {
+			case order.less(a, b):
+				return true
+			case order.less(b, a):
+				return false
+			}
+		}
+		return pvs[i].Second < pvs[j].Second
+	}
+	return Map(SortWith(pvs, less), func(pv Pair[V, int]) V {
+		return pv.First
+	})
+}
+
+// nonNil returns an empty slice instead of nil for not nil input values.
+func nonNil[V any](ivs, ovs []V) []V {
+	if ovs == nil && ivs != nil {
+		return []V{}
+	}
+	return ovs
+}
+
+// EOF
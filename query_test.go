@@ -0,0 +1,135 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// Sale is a record type for testing the queries.
+type Sale struct {
+	Region string
+	Seller string
+	Amount int
+}
+
+var sales = []Sale{
+	{"north", "ann", 300},
+	{"south", "bob", 150},
+	{"north", "cid", 120},
+	{"east", "dan", 500},
+	{"south", "eve", 150},
+	{"north", "ann", 80},
+}
+
+// TestQueryWhereSelect verifies filtering and converting values.
+func TestQueryWhereSelect(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	big := slices.NewQuery(sales).Where(func(s Sale) bool { return s.Amount >= 150 })
+	sellers := slices.Select(big, func(s Sale) string { return s.Seller })
+
+	assert.Equal(sellers.ToSlice(), []string{"ann", "bob", "dan", "eve"})
+	assert.Equal(big.Count(), 4)
+	assert.True(big.All(func(s Sale) bool { return s.Amount >= 150 }))
+	assert.True(big.Any(func(s Sale) bool { return s.Region == "east" }))
+	assert.False(big.Any(func(s Sale) bool { return s.Seller == "cid" }))
+
+	assert.Nil(slices.NewQuery[Sale](nil).Where(func(s Sale) bool { return true }).ToSlice())
+	assert.Equal(slices.NewQuery([]Sale{}).ToSlice(), []Sale{})
+}
+
+// TestQueryOrder verifies ordering by multiple criteria.
+func TestQueryOrder(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	seller := func(s Sale) string { return s.Seller }
+	byRegion := slices.By(func(s Sale) string { return s.Region })
+	byAmount := slices.By(func(s Sale) int { return s.Amount })
+	q := slices.NewQuery(sales)
+
+	assert.Equal(slices.Select(q.OrderBy(byAmount), seller).ToSlice(),
+		[]string{"ann", "cid", "bob", "eve", "ann", "dan"})
+	assert.Equal(slices.Select(q.OrderBy(byAmount).Descending(), seller).ToSlice(),
+		[]string{"dan", "ann", "bob", "eve", "cid", "ann"})
+	assert.Equal(slices.Select(q.OrderBy(byRegion).ThenBy(byAmount).Descending(), seller).ToSlice(),
+		[]string{"dan", "ann", "cid", "ann", "bob", "eve"})
+	assert.Equal(slices.Select(q.OrderBy(byRegion).Descending().ThenBy(byAmount), seller).ToSlice(),
+		[]string{"bob", "eve", "ann", "cid", "ann", "dan"})
+
+	// A later OrderBy() sorts again and keeps the former order for equal values.
+	assert.Equal(slices.Select(q.OrderBy(byAmount).OrderBy(byRegion), seller).ToSlice(),
+		[]string{"dan", "ann", "cid", "ann", "bob", "eve"})
+	// Queries are immutable.
+	ordered := q.OrderBy(byAmount)
+	_ = ordered.Descending()
+	assert.Equal(ordered.ToSlice()[0], Sale{"north", "ann", 80})
+}
+
+// TestQuerySkipTake verifies the paging of query results.
+func TestQuerySkipTake(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	q := slices.NewQuery([]int{5, 3, 8, 1, 9, 2})
+	less := func(a, b int) bool { return a < b }
+
+	assert.Equal(q.OrderBy(less).Skip(1).Take(3).ToSlice(), []int{2, 3, 5})
+	assert.Equal(q.Skip(0).ToSlice(), []int{5, 3, 8, 1, 9, 2})
+	assert.Equal(q.Skip(10).ToSlice(), []int{})
+	assert.Equal(q.Take(0).ToSlice(), []int{})
+	assert.Equal(q.Take(10).ToSlice(), []int{5, 3, 8, 1, 9, 2})
+	assert.Nil(slices.NewQuery[int](nil).Skip(1).Take(1).ToSlice())
+}
+
+// TestQueryDistinct verifies the dropping of duplicate keys.
+func TestQueryDistinct(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	q := slices.Distinct(slices.NewQuery(sales), func(s Sale) string { return s.Region })
+	regions := slices.Select(q, func(s Sale) string { return s.Region })
+
+	assert.Equal(regions.ToSlice(), []string{"north", "south", "east"})
+}
+
+// TestQueryGroupBy verifies grouping, filtering and aggregating groups.
+func TestQueryGroupBy(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	region := func(s Sale) string { return s.Region }
+	amounts := func(ss []Sale) int {
+		return slices.Sum(slices.Map(ss, func(s Sale) int { return s.Amount }))
+	}
+	groups := slices.GroupBy(slices.NewQuery(sales), region)
+
+	assert.Equal(groups.Count(), 3)
+	assert.Equal(groups.ToSlice()[1], slices.Group[string, Sale]{
+		Key:    "south",
+		Values: []Sale{{"south", "bob", 150}, {"south", "eve", 150}},
+	})
+
+	several := slices.Having(groups, func(g slices.Group[string, Sale]) bool { return len(g.Values) > 1 })
+	totals := slices.Aggregate(several, amounts).
+		OrderBy(slices.By(func(p slices.Pair[string, int]) int { return p.Second }))
+
+	assert.Equal(totals.ToSlice(), []slices.Pair[string, int]{{"south", 300}, {"north", 500}})
+	assert.Nil(slices.GroupBy(slices.NewQuery[Sale](nil), region).ToSlice())
+}
+
+// EOF
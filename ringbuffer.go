@@ -0,0 +1,126 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// TYPES
+//--------------------
+
+// OverflowPolicy defines what a full RingBuffer does with pushed values.
+type OverflowPolicy int
+
+// Overflow policies.
+const (
+	// Overwrite drops the oldest value for the new one.
+	Overwrite OverflowPolicy = iota
+
+	// Reject drops the new value.
+	Reject
+)
+
+//--------------------
+// RING BUFFER
+//--------------------
+
+// RingBuffer is a queue with a fixed capacity. Pushing and popping
+// take constant time.
+type RingBuffer[V any] struct {
+	values []V
+	start  int
+	length int
+	policy OverflowPolicy
+}
+
+// NewRingBuffer creates an empty ring buffer with the given capacity and
+// overflow policy. A capacity below one is set to one.
+func NewRingBuffer[V any](capacity int, policy OverflowPolicy) *RingBuffer[V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[V]{
+		values: make([]V, capacity),
+		policy: policy,
+	}
+}
+
+// RingBufferFromSlice creates a ring buffer and pushes the values of the
+// slice. If they exceed the capacity the overflow policy decides which
+// are kept.
+func RingBufferFromSlice[V any](ivs []V, capacity int, policy OverflowPolicy) *RingBuffer[V] {
+	rb := NewRingBuffer[V](capacity, policy)
+	for _, v := range ivs {
+		rb.Push(v)
+	}
+	return rb
+}
+
+// Cap returns the capacity.
+func (rb *RingBuffer[V]) Cap() int {
+	return len(rb.values)
+}
+
+// IsFull returns true if the number of values reached the capacity.
+func (rb *RingBuffer[V]) IsFull() bool {
+	return rb.length == len(rb.values)
+}
+
+// Len returns the number of values.
+func (rb *RingBuffer[V]) Len() int {
+	return rb.length
+}
+
+// Peek returns the oldest value without removing it. An empty ring buffer
+// returns the default value and false.
+func (rb *RingBuffer[V]) Peek() (V, bool) {
+	if rb.length == 0 {
+		var v V
+		return v, false
+	}
+	return rb.values[rb.start], true
+}
+
+// Pop removes and returns the oldest value. An empty ring buffer returns
+// the default value and false.
+func (rb *RingBuffer[V]) Pop() (V, bool) {
+	v, ok := rb.Peek()
+	if ok {
+		var zero V
+		rb.values[rb.start] = zero
+		rb.start = (rb.start + 1) % len(rb.values)
+		rb.length--
+	}
+	return v, ok
+}
+
+// Push adds a value. If the ring buffer is full the overflow policy
+// decides if the oldest value is overwritten or the new one is rejected.
+// In the latter case false is returned.
+func (rb *RingBuffer[V]) Push(v V) bool {
+	if rb.IsFull() {
+		if rb.policy == Reject {
+			return false
+		}
+		rb.values[rb.start] = v
+		rb.start = (rb.start + 1) % len(rb.values)
+		return true
+	}
+	rb.values[(rb.start+rb.length)%len(rb.values)] = v
+	rb.length++
+	return true
+}
+
+// ToSlice returns the values from the oldest to the newest as a new slice.
+func (rb *RingBuffer[V]) ToSlice() []V {
+	ovs := make([]V, rb.length)
+	for i := range ovs {
+		ovs[i] = rb.values[(rb.start+i)%len(rb.values)]
+	}
+	return ovs
+}
+
+// EOF
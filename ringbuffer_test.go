@@ -0,0 +1,87 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestRingBufferOverwrite verifies the overwriting of the oldest values.
+func TestRingBufferOverwrite(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	rb := slices.NewRingBuffer[int](3, slices.Overwrite)
+	assert.Equal(rb.Cap(), 3)
+	assert.Equal(rb.ToSlice(), []int{})
+
+	for i := 1; i <= 5; i++ {
+		assert.True(rb.Push(i))
+	}
+	assert.True(rb.IsFull())
+	assert.Equal(rb.Len(), 3)
+	assert.Equal(rb.ToSlice(), []int{3, 4, 5})
+
+	v, ok := rb.Pop()
+	assert.True(ok)
+	assert.Equal(v, 3)
+	assert.True(rb.Push(6))
+	assert.Equal(rb.ToSlice(), []int{4, 5, 6})
+}
+
+// TestRingBufferReject verifies the rejecting of new values.
+func TestRingBufferReject(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	rb := slices.NewRingBuffer[string](2, slices.Reject)
+
+	assert.True(rb.Push("a"))
+	assert.True(rb.Push("b"))
+	assert.False(rb.Push("c"))
+	assert.Equal(rb.ToSlice(), []string{"a", "b"})
+
+	v, ok := rb.Peek()
+	assert.True(ok)
+	assert.Equal(v, "a")
+	v, ok = rb.Pop()
+	assert.True(ok)
+	assert.Equal(v, "a")
+	v, ok = rb.Pop()
+	assert.True(ok)
+	assert.Equal(v, "b")
+	v, ok = rb.Pop()
+	assert.False(ok)
+	assert.Equal(v, "")
+	_, ok = rb.Peek()
+	assert.False(ok)
+}
+
+// TestRingBufferFromSlice verifies the creation out of slices.
+func TestRingBufferFromSlice(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{1, 2, 3, 4, 5}
+
+	assert.Equal(slices.RingBufferFromSlice(values, 3, slices.Overwrite).ToSlice(), []int{3, 4, 5})
+	assert.Equal(slices.RingBufferFromSlice(values, 3, slices.Reject).ToSlice(), []int{1, 2, 3})
+	assert.Equal(slices.RingBufferFromSlice(values, 10, slices.Reject).ToSlice(), values)
+	assert.Equal(slices.RingBufferFromSlice[int](nil, 0, slices.Reject).Cap(), 1)
+}
+
+// EOF
@@ -0,0 +1,98 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// TYPES
+//--------------------
+
+// Run is a value repeated Count times in a row.
+type Run[V any] struct {
+	Value V
+	Count int
+}
+
+//--------------------
+// RUN-LENGTH ENCODING
+//--------------------
+
+// RLEEncode returns the runs of equal consecutive values of the slice.
+func RLEEncode[V comparable](ivs []V) []Run[V] {
+	if ivs == nil {
+		return nil
+	}
+	runs := []Run[V]{}
+	for _, v := range ivs {
+		last := len(runs) - 1
+		if last >= 0 && runs[last].Value == v {
+			runs[last].Count++
+			continue
+		}
+		runs = append(runs, Run[V]{v, 1})
+	}
+	return runs
+}
+
+// RLEDecode creates a slice repeating each run value as often as its count.
+// Runs with a count below one are skipped.
+func RLEDecode[V any](runs []Run[V]) []V {
+	if runs == nil {
+		return nil
+	}
+	n := 0
+	for _, run := range runs {
+		if run.Count > 0 {
+			n += run.Count
+		}
+	}
+	ovs := make([]V, 0, n)
+	for _, run := range runs {
+		for i := 0; i < run.Count; i++ {
+			ovs = append(ovs, run.Value)
+		}
+	}
+	return ovs
+}
+
+// CountRuns returns the number of runs of equal consecutive values.
+func CountRuns[V comparable](ivs []V) int {
+	n := 0
+	for i, v := range ivs {
+		if i == 0 || ivs[i-1] != v {
+			n++
+		}
+	}
+	return n
+}
+
+// Dedupe collapses runs of equal consecutive values to one value like the
+// Unix uniq does. Other than Unique() equal values that are not neighbours
+// are kept.
+func Dedupe[V comparable](ivs []V) []V {
+	return DedupeWith(ivs, func(v V) V { return v })
+}
+
+// DedupeWith collapses runs of consecutive values with equal keys returned
+// by the key function to the first value of the run.
+func DedupeWith[V any, K comparable](ivs []V, key func(V) K) []V {
+	if ivs == nil {
+		return nil
+	}
+	ovs := []V{}
+	var last K
+	for i, v := range ivs {
+		k := key(v)
+		if i == 0 || k != last {
+			ovs = append(ovs, v)
+			last = k
+		}
+	}
+	return ovs
+}
+
+// EOF
@@ -0,0 +1,130 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"strings"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestRLEEncode verifies the run-length encoding of slices.
+func TestRLEEncode(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []string
+		out    []slices.Run[string]
+	}{
+		{
+			descr:  "Slice with runs",
+			values: []string{"a", "a", "a", "b", "c", "c", "a"},
+			out:    []slices.Run[string]{{"a", 3}, {"b", 1}, {"c", 2}, {"a", 1}},
+		}, {
+			descr:  "Slice without runs",
+			values: []string{"a", "b", "c"},
+			out:    []slices.Run[string]{{"a", 1}, {"b", 1}, {"c", 1}},
+		}, {
+			descr:  "Single value slice",
+			values: []string{"a", "a", "a"},
+			out:    []slices.Run[string]{{"a", 3}},
+		}, {
+			descr:  "Empty slice",
+			values: []string{},
+			out:    []slices.Run[string]{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		runs := slices.RLEEncode(test.values)
+		assert.Equal(runs, test.out)
+		assert.Equal(slices.RLEDecode(runs), test.values)
+		assert.Equal(slices.CountRuns(test.values), len(test.out))
+	}
+}
+
+// TestRLEDecode verifies the decoding of runs.
+func TestRLEDecode(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	runs := []slices.Run[int]{{1, 2}, {2, 0}, {3, -1}, {4, 1}, {1, 2}}
+
+	assert.Equal(slices.RLEDecode(runs), []int{1, 1, 4, 1, 1})
+	assert.Equal(slices.RLEDecode([]slices.Run[int]{}), []int{})
+	assert.Nil(slices.RLEDecode[int](nil))
+
+	gen := generators.New(generators.FixedRand())
+	ivs := gen.Ints(0, 3, 1000)
+	assert.Equal(slices.RLEDecode(slices.RLEEncode(ivs)), ivs)
+}
+
+// TestDedupe verifies the collapsing of consecutive duplicates.
+func TestDedupe(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []int
+		out    []int
+	}{
+		{
+			descr:  "Slice with runs",
+			values: []int{1, 1, 2, 3, 3, 3, 1, 1, 2},
+			out:    []int{1, 2, 3, 1, 2},
+		}, {
+			descr:  "Slice without runs",
+			values: []int{1, 2, 1, 2},
+			out:    []int{1, 2, 1, 2},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			out:    []int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Dedupe(test.values), test.out)
+	}
+}
+
+// TestDedupeWith verifies the collapsing of consecutive values with
+// equal keys.
+func TestDedupeWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ivs := []string{"Alpha", "ALPHA", "beta", "Beta", "alpha", ""}
+	out := slices.DedupeWith(ivs, strings.ToLower)
+
+	assert.Equal(out, []string{"Alpha", "beta", "alpha", ""})
+	assert.Nil(slices.DedupeWith(nil, strings.ToLower))
+}
+
+// EOF
@@ -0,0 +1,267 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// WarmUp defines how rolling functions fill the positions before the
+// first window is complete.
+type WarmUp int
+
+// Warm-up policies.
+const (
+	// WarmUpPartial computes the results of the incomplete windows.
+	WarmUpPartial WarmUp = iota
+
+	// WarmUpZero sets the results to the default value.
+	WarmUpZero
+
+	// WarmUpNaN sets float results to NaN. Other types get the
+	// default value.
+	WarmUpNaN
+)
+
+//--------------------
+// ROLLING
+//--------------------
+
+// Rolling calls the reducer for each window of values ending at each
+// position of the slice. So the result has the same length as the slice.
+// The warm-up policy controls the results before the first window is
+// complete. The reducer gets a view into the slice and must not modify
+// it. A window below one returns nil.
+func Rolling[V, R any](ivs []V, window int, reducer func([]V) R, warmup WarmUp) []R {
+	if ivs == nil || window < 1 {
+		return nil
+	}
+	ors := make([]R, len(ivs))
+	for i := range ivs {
+		if i < window-1 && warmup != WarmUpPartial {
+			ors[i] = warmUpValue[R](warmup)
+			continue
+		}
+		ors[i] = reducer(ivs[between(i-window+1, 0, i) : i+1])
+	}
+	return ors
+}
+
+// RollingMax returns the highest value of each window. It uses a monotonic
+// deque, so each value is handled in amortized constant time. Like Max()
+// a window containing NaN returns NaN.
+func RollingMax[V Number](ivs []V, window int, warmup WarmUp) []V {
+	return rollingExtreme(ivs, window, warmup, func(a, b V) bool { return a >= b })
+}
+
+// RollingMin returns the lowest value of each window. It uses a monotonic
+// deque, so each value is handled in amortized constant time. Like Min()
+// a window containing NaN returns NaN.
+func RollingMin[V Number](ivs []V, window int, warmup WarmUp) []V {
+	return rollingExtreme(ivs, window, warmup, func(a, b V) bool { return a <= b })
+}
+
+// RollingSum returns the sum of each window. It's updated with each step
+// instead of summing the whole window again. Float rounding errors are
+// compensated, and the window is summed again once per window length and
+// when a NaN or infinite value leaves it, so those don't spoil later sums.
+func RollingSum[V Number](ivs []V, window int, warmup WarmUp) []V {
+	if ivs == nil || window < 1 {
+		return nil
+	}
+	ovs := make([]V, len(ivs))
+	var sum compensatedSum[V]
+	for i, v := range ivs {
+		switch {
+		case i < window:
+			sum.add(v)
+		case i%window == 0 || !isFinite(ivs[i-window]):
+			sum = compensatedSum[V]{}
+			for _, wv := range ivs[i-window+1 : i+1] {
+				sum.add(wv)
+			}
+		default:
+			sum.add(v)
+			sum.add(-ivs[i-window])
+		}
+		if i < window-1 && warmup != WarmUpPartial {
+			ovs[i] = warmUpValue[V](warmup)
+			continue
+		}
+		ovs[i] = sum.value()
+	}
+	return ovs
+}
+
+//--------------------
+// MOVING AVERAGES
+//--------------------
+
+// ExponentialMovingAverage returns the exponential moving average of the
+// values. The smoothing factor is 2/(window+1) and the average is seeded
+// with the first value. The warm-up policy controls the results of the
+// first window-1 positions. A window below one returns nil.
+func ExponentialMovingAverage[V Number](ivs []V, window int, warmup WarmUp) []float64 {
+	if ivs == nil || window < 1 {
+		return nil
+	}
+	alpha := 2.0 / float64(window+1)
+	ofs := make([]float64, len(ivs))
+	ema := 0.0
+	for i, v := range ivs {
+		if i == 0 {
+			ema = float64(v)
+		} else {
+			ema = alpha*float64(v) + (1-alpha)*ema
+		}
+		if i < window-1 && warmup != WarmUpPartial {
+			ofs[i] = warmUpValue[float64](warmup)
+			continue
+		}
+		ofs[i] = ema
+	}
+	return ofs
+}
+
+// SimpleMovingAverage returns the arithmetic mean of each window. The
+// warm-up policy controls the results before the first window is complete.
+// A window below one returns nil.
+func SimpleMovingAverage[V Number](ivs []V, window int, warmup WarmUp) []float64 {
+	fs := Map(ivs, func(v V) float64 { return float64(v) })
+	sums := RollingSum(fs, window, warmup)
+	for i := range sums {
+		if i < window-1 {
+			if warmup != WarmUpPartial {
+				continue
+			}
+			sums[i] /= float64(i + 1)
+			continue
+		}
+		sums[i] /= float64(window)
+	}
+	return sums
+}
+
+// WeightedMovingAverage returns the linearly weighted mean of each window.
+// The newest value has the weight of the window size, the oldest one the
+// weight of one. The warm-up policy controls the results before the first
+// window is complete. A window below one returns nil.
+func WeightedMovingAverage[V Number](ivs []V, window int, warmup WarmUp) []float64 {
+	return Rolling(ivs, window, func(vs []V) float64 {
+		sum := 0.0
+		weights := 0.0
+		for i, v := range vs {
+			w := float64(i + 1)
+			sum += w * float64(v)
+			weights += w
+		}
+		return sum / weights
+	}, warmup)
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// warmUpValue returns the value for a warm-up position.
+func warmUpValue[R any](warmup WarmUp) R {
+	var r R
+	if warmup == WarmUpNaN {
+		switch p := any(&r).(type) {
+		case *float64:
+			*p = math.NaN()
+		case *float32:
+			*p = float32(math.NaN())
+		}
+	}
+	return r
+}
+
+// compensatedSum is a running sum with Neumaier compensation of float
+// rounding errors. For integers the compensation always stays zero.
+type compensatedSum[V Number] struct {
+	sum          V
+	compensation V
+}
+
+// add adds the value to the sum.
+func (cs *compensatedSum[V]) add(v V) {
+	t := cs.sum + v
+	if !isFinite(t) {
+		cs.sum = t
+		return
+	}
+	if magnitude(cs.sum) >= magnitude(v) {
+		cs.compensation += (cs.sum - t) + v
+	} else {
+		cs.compensation += (v - t) + cs.sum
+	}
+	cs.sum = t
+}
+
+// value returns the compensated sum.
+func (cs *compensatedSum[V]) value() V {
+	if !isFinite(cs.sum) {
+		return cs.sum
+	}
+	return cs.sum + cs.compensation
+}
+
+// magnitude returns the absolute value.
+func magnitude[V Number](v V) V {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// rollingExtreme returns the extreme value of each window. The function
+// dominates returns true if the first value replaces the second one as
+// extreme. The deque keeps the indices of the candidates. NaN values
+// cannot be compared, so they are not added to the deque but remembered
+// by the position of the last one.
+func rollingExtreme[V Number](ivs []V, window int, warmup WarmUp, dominates func(a, b V) bool) []V {
+	if ivs == nil || window < 1 {
+		return nil
+	}
+	ovs := make([]V, len(ivs))
+	deque := make([]int, 0, window)
+	lastNaN := -window
+	for i, v := range ivs {
+		if len(deque) > 0 && deque[0] <= i-window {
+			deque = deque[1:]
+		}
+		if isNaN(v) {
+			lastNaN = i
+		} else {
+			for len(deque) > 0 && dominates(v, ivs[deque[len(deque)-1]]) {
+				deque = deque[:len(deque)-1]
+			}
+			deque = append(deque, i)
+		}
+		switch {
+		case i < window-1 && warmup != WarmUpPartial:
+			ovs[i] = warmUpValue[V](warmup)
+		case lastNaN > i-window:
+			ovs[i] = ivs[lastNaN]
+		default:
+			ovs[i] = ivs[deque[0]]
+		}
+	}
+	return ovs
+}
+
+// EOF
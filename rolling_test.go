@@ -0,0 +1,186 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestRolling verifies the generic rolling with a reducer.
+func TestRolling(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	count := func(vs []string) int { return len(vs) }
+	values := []string{"a", "b", "c", "d"}
+
+	assert.Equal(slices.Rolling(values, 3, count, slices.WarmUpPartial), []int{1, 2, 3, 3})
+	assert.Equal(slices.Rolling(values, 3, count, slices.WarmUpZero), []int{0, 0, 3, 3})
+	assert.Equal(slices.Rolling(values, 3, count, slices.WarmUpNaN), []int{0, 0, 3, 3})
+	assert.Equal(slices.Rolling(values, 5, count, slices.WarmUpZero), []int{0, 0, 0, 0})
+	assert.Nil(slices.Rolling(values, 0, count, slices.WarmUpZero))
+	assert.Nil(slices.Rolling(nil, 3, count, slices.WarmUpZero))
+}
+
+// TestRollingMinMax verifies the rolling extremes.
+func TestRollingMinMax(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{4, 2, 12, 3, 8, 1, 1, 7}
+
+	assert.Equal(slices.RollingMin(values, 3, slices.WarmUpPartial), []int{4, 2, 2, 2, 3, 1, 1, 1})
+	assert.Equal(slices.RollingMax(values, 3, slices.WarmUpPartial), []int{4, 4, 12, 12, 12, 8, 8, 7})
+	assert.Equal(slices.RollingMax(values, 3, slices.WarmUpZero), []int{0, 0, 12, 12, 12, 8, 8, 7})
+	assert.Equal(slices.RollingMin(values, 1, slices.WarmUpZero), values)
+
+	mins := slices.RollingMin([]float64{3, 1, 2}, 2, slices.WarmUpNaN)
+	assert.True(math.IsNaN(mins[0]))
+	assert.Equal(mins[1:], []float64{1, 1})
+
+	// A NaN gives NaN as long as it is inside the window, like Min() and Max().
+	nan := math.NaN()
+	nans := []float64{5, nan, 1, 2, 7, nan, nan, 3}
+	assert.Equal(fmt.Sprint(slices.RollingMin(nans, 3, slices.WarmUpPartial)), "[5 NaN NaN NaN 1 NaN NaN NaN]")
+	assert.Equal(fmt.Sprint(slices.RollingMax(nans, 2, slices.WarmUpPartial)), "[5 NaN NaN 2 7 NaN NaN NaN]")
+	assert.Equal(fmt.Sprint(slices.RollingMax(nans, 1, slices.WarmUpPartial)), fmt.Sprint(nans))
+	assert.Equal(fmt.Sprint(slices.RollingMin(nans, 3, slices.WarmUpPartial)),
+		fmt.Sprint(slices.Rolling(nans, 3, slices.Min[float64], slices.WarmUpPartial)))
+
+	// Compare with the generic rolling.
+	gen := generators.New(generators.FixedRand())
+	rvs := gen.Ints(0, 1000, 500)
+	assert.Equal(slices.RollingMax(rvs, 17, slices.WarmUpPartial),
+		slices.Rolling(rvs, 17, slices.Max[int], slices.WarmUpPartial))
+}
+
+// TestRollingSum verifies the rolling sums.
+func TestRollingSum(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{1, 2, 3, 4, 5}
+
+	assert.Equal(slices.RollingSum(values, 2, slices.WarmUpPartial), []int{1, 3, 5, 7, 9})
+	assert.Equal(slices.RollingSum(values, 3, slices.WarmUpZero), []int{0, 0, 6, 9, 12})
+	assert.Equal(slices.RollingSum([]int{}, 3, slices.WarmUpZero), []int{})
+	assert.Nil(slices.RollingSum[int](nil, 3, slices.WarmUpZero))
+	assert.Equal(slices.RollingSum([]uint8{200, 100, 50, 5}, 2, slices.WarmUpPartial), []uint8{200, 44, 150, 55})
+
+	// Large magnitudes don't cancel out small values.
+	large := []float64{1e17, 1, 1, 1, 1}
+	assert.Equal(slices.RollingSum(large, 1, slices.WarmUpPartial), large)
+	assert.Equal(slices.RollingSum(large, 2, slices.WarmUpPartial), []float64{1e17, 1e17, 2, 2, 2})
+	assert.Equal(slices.RollingSum([]float64{1, 1e17, 1, 1, 1, 1}, 3, slices.WarmUpPartial),
+		[]float64{1, 1e17 + 1, 1e17 + 2, 1e17 + 2, 3, 3})
+
+	// NaN and infinite values only affect their windows.
+	nan, inf := math.NaN(), math.Inf(1)
+	assert.Equal(fmt.Sprint(slices.RollingSum([]float64{1, nan, 3, 4, 5, 6}, 2, slices.WarmUpPartial)),
+		"[1 NaN NaN 7 9 11]")
+	assert.Equal(fmt.Sprint(slices.RollingSum([]float64{1, inf, 3, 4, -inf, 6, 7, 8, 9}, 4, slices.WarmUpPartial)),
+		"[1 +Inf +Inf +Inf NaN -Inf -Inf -Inf 30]")
+
+	// Compare with the generic rolling.
+	gen := generators.New(generators.FixedRand())
+	fvs := slices.Map(gen.Ints(-1000000, 1000000, 500), func(i int) float64 { return float64(i) / 1000 })
+	sums := slices.RollingSum(fvs, 17, slices.WarmUpPartial)
+	for i, sum := range slices.Rolling(fvs, 17, slices.Sum[float64], slices.WarmUpPartial) {
+		assert.About(sums[i], sum, 1e-9)
+	}
+}
+
+// TestSimpleMovingAverage verifies the simple moving average.
+func TestSimpleMovingAverage(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{2, 4, 6, 8, 10}
+
+	assert.Equal(slices.SimpleMovingAverage(values, 2, slices.WarmUpPartial), []float64{2, 3, 5, 7, 9})
+	assert.Equal(slices.SimpleMovingAverage(values, 3, slices.WarmUpZero), []float64{0, 0, 4, 6, 8})
+
+	smas := slices.SimpleMovingAverage(values, 3, slices.WarmUpNaN)
+	assert.Length(smas, len(values))
+	assert.True(math.IsNaN(smas[0]))
+	assert.True(math.IsNaN(smas[1]))
+	assert.Equal(smas[2:], []float64{4, 6, 8})
+	assert.Nil(slices.SimpleMovingAverage(values, 0, slices.WarmUpZero))
+
+	// A NaN only affects its windows.
+	smas = slices.SimpleMovingAverage([]float64{1, math.NaN(), 3, 4, 5, 6, 7}, 2, slices.WarmUpPartial)
+	assert.Equal(fmt.Sprint(smas), "[1 NaN NaN 3.5 4.5 5.5 6.5]")
+}
+
+// TestExponentialMovingAverage verifies the exponential moving average.
+func TestExponentialMovingAverage(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []float64{1, 2, 3, 4}
+
+	// Window 3 leads to a smoothing factor of 0.5.
+	assert.Equal(slices.ExponentialMovingAverage(values, 3, slices.WarmUpPartial), []float64{1, 1.5, 2.25, 3.125})
+	assert.Equal(slices.ExponentialMovingAverage(values, 3, slices.WarmUpZero), []float64{0, 0, 2.25, 3.125})
+	assert.Equal(slices.ExponentialMovingAverage(values, 1, slices.WarmUpZero), values)
+	assert.Nil(slices.ExponentialMovingAverage[float64](nil, 3, slices.WarmUpZero))
+}
+
+// TestWeightedMovingAverage verifies the weighted moving average.
+func TestWeightedMovingAverage(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{3, 6, 9, 3}
+
+	wmas := slices.WeightedMovingAverage(values, 2, slices.WarmUpPartial)
+	assert.Length(wmas, 4)
+	assert.About(wmas[0], 3.0, 0.0001)
+	assert.About(wmas[1], 5.0, 0.0001)
+	assert.About(wmas[2], 8.0, 0.0001)
+	assert.About(wmas[3], 5.0, 0.0001)
+
+	wmas = slices.WeightedMovingAverage(values, 3, slices.WarmUpZero)
+	assert.Equal(wmas[:2], []float64{0, 0})
+	assert.About(wmas[2], 7.0, 0.0001)
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkRollingMax runs a performance test on the rolling maximum.
+func BenchmarkRollingMax(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vs := gen.Ints(0, 1000, 10000)
+
+	for i := 0; i < b.N; i++ {
+		slices.RollingMax(vs, 100, slices.WarmUpPartial)
+	}
+}
+
+// BenchmarkRollingSum runs a performance test on the rolling sum.
+func BenchmarkRollingSum(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	fs := slices.Map(gen.Ints(0, 1000, 10000), func(i int) float64 { return float64(i) / 10 })
+
+	for i := 0; i < b.N; i++ {
+		slices.RollingSum(fs, 100, slices.WarmUpPartial)
+	}
+}
+
+// EOF
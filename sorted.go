@@ -0,0 +1,177 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// SORTED SLICE
+//--------------------
+
+// SortedSlice contains values always sorted in ascending order. Lookups
+// use a binary search. Values are equal if none is less than the other.
+type SortedSlice[V any] struct {
+	values []V
+	less   func(a, b V) bool
+}
+
+// NewSortedSlice creates a sorted slice containing the given values.
+func NewSortedSlice[V constraints.Ordered](ivs ...V) *SortedSlice[V] {
+	return &SortedSlice[V]{
+		values: Sort(ivs),
+		less: func(a, b V) bool {
+			return a < b
+		},
+	}
+}
+
+// NewSortedSliceWith creates a sorted slice containing the given values.
+// Instead of having to fulfil a constraint any value type can be used.
+// The given less function must do the comparison of two values.
+func NewSortedSliceWith[V any](less func(a, b V) bool, ivs ...V) *SortedSlice[V] {
+	return &SortedSlice[V]{
+		values: SortWith(ivs, func(vs []V, i, j int) bool {
+			return less(vs[i], vs[j])
+		}),
+		less: less,
+	}
+}
+
+// Contains returns true if the sorted slice contains the value v.
+func (ss *SortedSlice[V]) Contains(v V) bool {
+	i := ss.Rank(v)
+	return i < len(ss.values) && !ss.less(v, ss.values[i])
+}
+
+// Insert adds the values at their sorted positions. Equal values are
+// inserted behind the existing ones.
+func (ss *SortedSlice[V]) Insert(vs ...V) {
+	for _, v := range vs {
+		i := ss.upperBound(v)
+		ss.values = append(ss.values, v)
+		copy(ss.values[i+1:], ss.values[i:])
+		ss.values[i] = v
+	}
+}
+
+// Intersect returns a new sorted slice containing the values contained in
+// both sorted slices. Values contained multiple times in both are kept as
+// often as in the one containing them less often. The comparison is done
+// in one pass with the less function of this sorted slice.
+func (ss *SortedSlice[V]) Intersect(other *SortedSlice[V]) *SortedSlice[V] {
+	values := []V{}
+	a, b := ss.values, other.values
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case ss.less(a[i], b[j]):
+			i++
+		case ss.less(b[j], a[i]):
+			j++
+		default:
+			values = append(values, a[i])
+			i++
+			j++
+		}
+	}
+	return &SortedSlice[V]{values: values, less: ss.less}
+}
+
+// Len returns the number of values.
+func (ss *SortedSlice[V]) Len() int {
+	return len(ss.values)
+}
+
+// Range returns all values from lo up to and including hi as new slice.
+func (ss *SortedSlice[V]) Range(lo, hi V) []V {
+	return Subslice(ss.values, ss.Rank(lo), ss.upperBound(hi)-1)
+}
+
+// Rank returns the number of values less than v. It's also the position
+// of the first value equal to v if it is contained.
+func (ss *SortedSlice[V]) Rank(v V) int {
+	lo, hi := 0, len(ss.values)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if ss.less(ss.values[mid], v) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Remove deletes the first value equal to v and returns true if one
+// has been found.
+func (ss *SortedSlice[V]) Remove(v V) bool {
+	i := ss.Rank(v)
+	if i == len(ss.values) || ss.less(v, ss.values[i]) {
+		return false
+	}
+	ss.values = append(ss.values[:i], ss.values[i+1:]...)
+	return true
+}
+
+// ToSlice returns the sorted values as a new slice.
+func (ss *SortedSlice[V]) ToSlice() []V {
+	return Copy(ss.values)
+}
+
+// Union returns a new sorted slice containing the values of both sorted
+// slices. Values contained multiple times are kept as often as in the one
+// containing them more often. The merging is done in one pass with the
+// less function of this sorted slice.
+func (ss *SortedSlice[V]) Union(other *SortedSlice[V]) *SortedSlice[V] {
+	a, b := ss.values, other.values
+	values := make([]V, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case ss.less(a[i], b[j]):
+			values = append(values, a[i])
+			i++
+		case ss.less(b[j], a[i]):
+			values = append(values, b[j])
+			j++
+		default:
+			values = append(values, a[i])
+			i++
+			j++
+		}
+	}
+	values = append(values, a[i:]...)
+	values = append(values, b[j:]...)
+	return &SortedSlice[V]{values: values, less: ss.less}
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// upperBound returns the position behind the last value equal to v.
+func (ss *SortedSlice[V]) upperBound(v V) int {
+	lo, hi := 0, len(ss.values)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if ss.less(v, ss.values[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// EOF
@@ -0,0 +1,116 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestSortedSliceInsert verifies the keeping of the order when inserting.
+func TestSortedSliceInsert(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ss := slices.NewSortedSlice(5, 1, 3)
+	assert.Equal(ss.ToSlice(), []int{1, 3, 5})
+
+	ss.Insert(4, 0, 6, 3)
+	assert.Equal(ss.ToSlice(), []int{0, 1, 3, 3, 4, 5, 6})
+	assert.Equal(ss.Len(), 7)
+
+	gen := generators.New(generators.FixedRand())
+	ss = slices.NewSortedSlice[int]()
+	ss.Insert(gen.Ints(0, 100, 1000)...)
+	assert.Equal(ss.Len(), 1000)
+	assert.True(slices.IsSorted(ss.ToSlice()))
+}
+
+// TestSortedSliceLookup verifies the binary search based lookups.
+func TestSortedSliceLookup(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ss := slices.NewSortedSlice(1, 3, 3, 5, 7, 9)
+
+	assert.True(ss.Contains(3))
+	assert.True(ss.Contains(9))
+	assert.False(ss.Contains(4))
+	assert.False(ss.Contains(10))
+	assert.Equal(ss.Rank(0), 0)
+	assert.Equal(ss.Rank(3), 1)
+	assert.Equal(ss.Rank(4), 3)
+	assert.Equal(ss.Rank(10), 6)
+	assert.Equal(ss.Range(3, 7), []int{3, 3, 5, 7})
+	assert.Equal(ss.Range(2, 6), []int{3, 3, 5})
+	assert.Equal(ss.Range(8, 20), []int{9})
+	assert.Nil(ss.Range(10, 20))
+	assert.Nil(ss.Range(7, 3))
+
+	empty := slices.NewSortedSlice[int]()
+	assert.False(empty.Contains(1))
+	assert.Equal(empty.Rank(1), 0)
+	assert.Nil(empty.Range(0, 10))
+}
+
+// TestSortedSliceRemove verifies the removing of values.
+func TestSortedSliceRemove(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ss := slices.NewSortedSlice(1, 3, 3, 5)
+
+	assert.True(ss.Remove(3))
+	assert.Equal(ss.ToSlice(), []int{1, 3, 5})
+	assert.False(ss.Remove(4))
+	assert.True(ss.Remove(5))
+	assert.True(ss.Remove(1))
+	assert.Equal(ss.ToSlice(), []int{3})
+}
+
+// TestSortedSliceWith verifies the sorted slice with a less function.
+func TestSortedSliceWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	less := func(a, b string) bool { return len(a) < len(b) }
+	ss := slices.NewSortedSliceWith(less, "alpha", "pi", "epsilon")
+
+	assert.Equal(ss.ToSlice(), []string{"pi", "alpha", "epsilon"})
+	ss.Insert("beta")
+	assert.Equal(ss.ToSlice(), []string{"pi", "beta", "alpha", "epsilon"})
+	assert.True(ss.Contains("zeta"))
+	assert.Equal(ss.Range("phi", "gamma"), []string{"beta", "alpha"})
+}
+
+// TestSortedSliceSetOperations verifies union and intersection.
+func TestSortedSliceSetOperations(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	a := slices.NewSortedSlice(1, 2, 2, 3, 5)
+	b := slices.NewSortedSlice(2, 3, 3, 4)
+
+	assert.Equal(a.Union(b).ToSlice(), []int{1, 2, 2, 3, 3, 4, 5})
+	assert.Equal(a.Intersect(b).ToSlice(), []int{2, 3})
+	assert.Equal(a.Union(slices.NewSortedSlice[int]()).ToSlice(), a.ToSlice())
+	assert.Equal(a.Intersect(slices.NewSortedSlice[int]()).ToSlice(), []int{})
+
+	// Operands stay unchanged.
+	assert.Equal(a.ToSlice(), []int{1, 2, 2, 3, 5})
+	assert.Equal(b.ToSlice(), []int{2, 3, 3, 4})
+}
+
+// EOF
@@ -0,0 +1,304 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// QuantileMethod defines how a quantile lying between two values
+// is computed.
+type QuantileMethod int
+
+// Quantile methods.
+const (
+	// Linear interpolates between the two values.
+	Linear QuantileMethod = iota
+
+	// Lower takes the lower of the two values.
+	Lower
+
+	// Higher takes the higher of the two values.
+	Higher
+
+	// Nearest takes the value nearest to the quantile position.
+	Nearest
+
+	// Midpoint takes the mean of the two values.
+	Midpoint
+)
+
+// Bin is one bin of a histogram. It counts the values from Lower up to
+// but not including Upper. Only the last bin of a histogram includes its
+// Upper value.
+type Bin struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+//--------------------
+// AGGREGATION
+//--------------------
+
+// Max returns the highest value of the slice or the default value if it
+// is empty. If one of the values is NaN the result is NaN.
+func Max[V Number](ivs []V) V {
+	_, highest := MinMax(ivs)
+	return highest
+}
+
+// MaxBy returns the value of the slice with the highest key returned by
+// the key function or the default value if the slice is empty. The first
+// one wins if multiple values have the same key.
+func MaxBy[V any, K constraints.Ordered](ivs []V, key func(V) K) V {
+	return FoldLFirst(ivs, func(v, acc V) V {
+		if key(v) > key(acc) {
+			return v
+		}
+		return acc
+	})
+}
+
+// Min returns the lowest value of the slice or the default value if it
+// is empty. If one of the values is NaN the result is NaN.
+func Min[V Number](ivs []V) V {
+	lowest, _ := MinMax(ivs)
+	return lowest
+}
+
+// MinBy returns the value of the slice with the lowest key returned by
+// the key function or the default value if the slice is empty. The first
+// one wins if multiple values have the same key.
+func MinBy[V any, K constraints.Ordered](ivs []V, key func(V) K) V {
+	return FoldLFirst(ivs, func(v, acc V) V {
+		if key(v) < key(acc) {
+			return v
+		}
+		return acc
+	})
+}
+
+// MinMax returns the lowest and the highest value of the slice in one
+// pass or the default values if it is empty. If one of the values is NaN
+// both results are NaN.
+func MinMax[V Number](ivs []V) (V, V) {
+	var lowest, highest V
+	for i, v := range ivs {
+		switch {
+		case isNaN(v):
+			return v, v
+		case i == 0:
+			lowest, highest = v, v
+		case v < lowest:
+			lowest = v
+		case v > highest:
+			highest = v
+		}
+	}
+	return lowest, highest
+}
+
+// Product returns the product of all values of the slice. An empty slice
+// returns 1. Integer types wrap around on overflow like the Go operators
+// do, float types become infinite.
+func Product[V Number](ivs []V) V {
+	return FoldL(ivs, V(1), func(v, acc V) V {
+		return acc * v
+	})
+}
+
+// Sum returns the sum of all values of the slice. Integer types wrap
+// around on overflow like the Go operators do, float types become
+// infinite.
+func Sum[V Number](ivs []V) V {
+	return FoldL(ivs, V(0), func(v, acc V) V {
+		return acc + v
+	})
+}
+
+//--------------------
+// STATISTICS
+//--------------------
+
+// Mean returns the arithmetic mean of the values. It is computed as running
+// mean in float64, so it does not overflow like Sum(). An empty slice
+// returns NaN.
+func Mean[V Number](ivs []V) float64 {
+	mean, _ := meanVariance(ivs)
+	return mean
+}
+
+// Median returns the value in the middle of the sorted values. For an
+// even number of values it's the mean of the two middle ones. An empty
+// slice or one containing NaN returns NaN.
+func Median[V Number](ivs []V) float64 {
+	return Quantile(ivs, 0.5, Linear)
+}
+
+// Mode returns the most frequent values in the order of their first
+// appearance. NaN values are not counted.
+func Mode[V Number](ivs []V) []V {
+	if ivs == nil {
+		return nil
+	}
+	counts := map[V]int{}
+	most := 0
+	for _, v := range ivs {
+		if isNaN(v) {
+			continue
+		}
+		counts[v]++
+		if counts[v] > most {
+			most = counts[v]
+		}
+	}
+	return UniqueWith(Filter(ivs, func(v V) bool {
+		return !isNaN(v) && counts[v] == most
+	}), func(v V) V {
+		return v
+	})
+}
+
+// Percentile returns the p-th percentile of the values with p between
+// 0 and 100. See Quantile() for details.
+func Percentile[V Number](ivs []V, p float64, method QuantileMethod) float64 {
+	return Quantile(ivs, p/100, method)
+}
+
+// Quantile returns the q-th quantile of the values with q between 0 and 1.
+// The method defines how a quantile between two values is computed. An
+// empty slice, one containing NaN, or an invalid q returns NaN.
+func Quantile[V Number](ivs []V, q float64, method QuantileMethod) float64 {
+	if len(ivs) == 0 || q < 0 || q > 1 || math.IsNaN(q) || ContainsAny(ivs, isNaN[V]) {
+		return math.NaN()
+	}
+	svs := Sort(ivs)
+	pos := q * float64(len(svs)-1)
+	if math.Abs(pos-math.Round(pos)) < 1e-9 {
+		// Don't let rounding errors select the wrong value.
+		pos = math.Round(pos)
+	}
+	lo := float64(svs[int(math.Floor(pos))])
+	hi := float64(svs[int(math.Ceil(pos))])
+	switch method {
+	case Lower:
+		return lo
+	case Higher:
+		return hi
+	case Nearest:
+		return float64(svs[int(math.Round(pos))])
+	case Midpoint:
+		return (lo + hi) / 2
+	}
+	return lo + (hi-lo)*(pos-math.Floor(pos))
+}
+
+// StdDev returns the population standard deviation of the values. An
+// empty slice returns NaN.
+func StdDev[V Number](ivs []V) float64 {
+	return math.Sqrt(Variance(ivs))
+}
+
+// Variance returns the population variance of the values. It's computed
+// in one pass with the numerically stable Welford algorithm. An empty
+// slice returns NaN.
+func Variance[V Number](ivs []V) float64 {
+	_, variance := meanVariance(ivs)
+	return variance
+}
+
+//--------------------
+// HISTOGRAM
+//--------------------
+
+// Histogram counts the values in n bins of equal width between the lowest
+// and the highest value. NaN and infinite values are ignored, as they
+// cannot be placed in bins of finite width. An empty slice, one without
+// finite values, or n below one return nil.
+func Histogram[V Number](ivs []V, n int) []Bin {
+	fvs := Filter(Map(ivs, func(v V) float64 { return float64(v) }), isFinite[float64])
+	if len(fvs) == 0 || n < 1 {
+		return nil
+	}
+	lowest, highest := MinMax(fvs)
+	// Dividing first keeps the width finite for extreme values.
+	width := highest/float64(n) - lowest/float64(n)
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = lowest + float64(i)*width
+	}
+	edges[n] = highest
+	return HistogramWithEdges(fvs, edges)
+}
+
+// HistogramWithEdges counts the values in the bins defined by the ascending
+// edges. n edges define n-1 bins. Values outside the edges as well as NaN
+// values are ignored. Less than two edges return nil.
+func HistogramWithEdges[V Number](ivs []V, edges []float64) []Bin {
+	if len(edges) < 2 {
+		return nil
+	}
+	bins := make([]Bin, len(edges)-1)
+	for i := range bins {
+		bins[i].Lower = edges[i]
+		bins[i].Upper = edges[i+1]
+	}
+	last := len(bins) - 1
+	for _, v := range ivs {
+		f := float64(v)
+		if math.IsNaN(f) || f < edges[0] || f > edges[last+1] {
+			continue
+		}
+		// Binary search for the last edge not greater than the value.
+		lo, hi := 0, last
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			if edges[mid] <= f {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		bins[lo].Count++
+	}
+	return bins
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// meanVariance computes mean and population variance with the Welford
+// algorithm.
+func meanVariance[V Number](ivs []V) (float64, float64) {
+	if len(ivs) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	mean := 0.0
+	m2 := 0.0
+	for i, v := range ivs {
+		f := float64(v)
+		delta := f - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (f - mean)
+	}
+	return mean, m2 / float64(len(ivs))
+}
+
+// EOF
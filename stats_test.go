@@ -0,0 +1,223 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestSumProduct verifies the summing and multiplying of values.
+func TestSumProduct(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Sum([]int{1, 2, 3, 4}), 10)
+	assert.Equal(slices.Product([]int{1, 2, 3, 4}), 24)
+	assert.Equal(slices.Sum([]int{}), 0)
+	assert.Equal(slices.Product([]int{}), 1)
+	assert.Equal(slices.Sum[int](nil), 0)
+	assert.About(slices.Sum([]float64{0.5, 0.25}), 0.75, 0.0001)
+
+	// Overflow wraps integers and makes floats infinite.
+	assert.Equal(slices.Sum([]int8{100, 100}), int8(-56))
+	assert.Equal(slices.Product([]uint8{16, 16}), uint8(0))
+	assert.True(math.IsInf(slices.Sum([]float64{math.MaxFloat64, math.MaxFloat64}), 1))
+
+	// NaN propagates.
+	assert.True(math.IsNaN(slices.Sum([]float64{1, math.NaN(), 2})))
+	assert.True(math.IsNaN(slices.Product([]float64{1, math.NaN(), 2})))
+}
+
+// TestMinMax verifies the finding of lowest and highest values.
+func TestMinMax(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values []float64
+		min    float64
+		max    float64
+	}{
+		{
+			descr:  "Multiple values",
+			values: []float64{3, -1, 4, 1, 5, -9, 2},
+			min:    -9,
+			max:    5,
+		}, {
+			descr:  "Single value",
+			values: []float64{42},
+			min:    42,
+			max:    42,
+		}, {
+			descr:  "Infinite values",
+			values: []float64{1, math.Inf(1), math.Inf(-1)},
+			min:    math.Inf(-1),
+			max:    math.Inf(1),
+		}, {
+			descr:  "Empty slice",
+			values: []float64{},
+			min:    0,
+			max:    0,
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			min:    0,
+			max:    0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		min, max := slices.MinMax(test.values)
+		assert.Equal(min, test.min)
+		assert.Equal(max, test.max)
+		assert.Equal(slices.Min(test.values), test.min)
+		assert.Equal(slices.Max(test.values), test.max)
+	}
+
+	min, max := slices.MinMax([]float64{1, math.NaN(), 2})
+	assert.True(math.IsNaN(min))
+	assert.True(math.IsNaN(max))
+	assert.Equal(slices.Max([]uint{1, math.MaxUint, 2}), uint(math.MaxUint))
+}
+
+// TestMinMaxBy verifies the finding of lowest and highest values by key.
+func TestMinMaxBy(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	length := func(s string) int { return len(s) }
+	values := []string{"beta", "pi", "epsilon", "mu", "lambda", "omicron"}
+
+	assert.Equal(slices.MinBy(values, length), "pi")
+	assert.Equal(slices.MaxBy(values, length), "epsilon")
+	assert.Equal(slices.MinBy(nil, length), "")
+	assert.Equal(slices.MaxBy(nil, length), "")
+}
+
+// TestMeanVariance verifies the computing of mean, variance, and standard
+// deviation.
+func TestMeanVariance(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.About(slices.Mean(values), 5.0, 0.0001)
+	assert.About(slices.Variance(values), 4.0, 0.0001)
+	assert.About(slices.StdDev(values), 2.0, 0.0001)
+	assert.About(slices.Variance([]float64{1}), 0.0, 0.0001)
+
+	// Mean does not overflow.
+	assert.About(slices.Mean([]int8{100, 100, 100}), 100.0, 0.0001)
+	assert.Equal(slices.Mean([]float64{math.MaxFloat64, math.MaxFloat64}), math.MaxFloat64)
+
+	// Empty slices and NaN.
+	assert.True(math.IsNaN(slices.Mean([]int{})))
+	assert.True(math.IsNaN(slices.Variance[int](nil)))
+	assert.True(math.IsNaN(slices.StdDev[int](nil)))
+	assert.True(math.IsNaN(slices.Mean([]float64{1, math.NaN()})))
+}
+
+// TestMedian verifies the computing of the median.
+func TestMedian(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Median([]int{5, 1, 3}), 3.0)
+	assert.Equal(slices.Median([]int{4, 1, 3, 2}), 2.5)
+	assert.Equal(slices.Median([]int{7}), 7.0)
+	assert.True(math.IsNaN(slices.Median([]int{})))
+	assert.True(math.IsNaN(slices.Median([]float64{1, math.NaN(), 3})))
+}
+
+// TestMode verifies the finding of the most frequent values.
+func TestMode(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Mode([]int{1, 2, 2, 3, 3, 3}), []int{3})
+	assert.Equal(slices.Mode([]int{3, 1, 1, 3, 2}), []int{3, 1})
+	assert.Equal(slices.Mode([]float64{math.NaN(), math.NaN(), 1}), []float64{1})
+	assert.Equal(slices.Mode([]int{}), []int{})
+	assert.Nil(slices.Mode[int](nil))
+}
+
+// TestQuantile verifies the computing of quantiles and percentiles.
+func TestQuantile(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := []int{10, 1, 7, 4}
+	tests := []struct {
+		descr  string
+		q      float64
+		method slices.QuantileMethod
+		out    float64
+	}{
+		{"Linear lower quartile", 0.25, slices.Linear, 3.25},
+		{"Lower lower quartile", 0.25, slices.Lower, 1.0},
+		{"Higher lower quartile", 0.25, slices.Higher, 4.0},
+		{"Nearest lower quartile", 0.25, slices.Nearest, 4.0},
+		{"Midpoint lower quartile", 0.25, slices.Midpoint, 2.5},
+		{"Linear minimum", 0.0, slices.Linear, 1.0},
+		{"Linear maximum", 1.0, slices.Linear, 10.0},
+		{"Lower exact position", 1.0 / 3.0, slices.Lower, 4.0},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.About(slices.Quantile(values, test.q, test.method), test.out, 0.0001)
+		assert.About(slices.Percentile(values, test.q*100, test.method), test.out, 0.0001)
+	}
+
+	assert.True(math.IsNaN(slices.Quantile(values, 1.5, slices.Linear)))
+	assert.True(math.IsNaN(slices.Quantile(values, -0.5, slices.Linear)))
+	assert.True(math.IsNaN(slices.Quantile([]int{}, 0.5, slices.Linear)))
+	assert.True(math.IsNaN(slices.Quantile([]float64{1, math.NaN()}, 0.5, slices.Linear)))
+}
+
+// TestHistogram verifies the counting of values in bins.
+func TestHistogram(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	assert.Equal(slices.Histogram([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}, 2), []slices.Bin{
+		{Lower: 0, Upper: 5, Count: 5},
+		{Lower: 5, Upper: 10, Count: 5},
+	})
+	assert.Equal(slices.Histogram([]float64{1, math.NaN(), 1}, 2), []slices.Bin{
+		{Lower: 1, Upper: 1, Count: 0},
+		{Lower: 1, Upper: 1, Count: 2},
+	})
+	assert.Equal(slices.Histogram([]float64{1, math.Inf(1), 3, math.Inf(-1)}, 2), []slices.Bin{
+		{Lower: 1, Upper: 2, Count: 1},
+		{Lower: 2, Upper: 3, Count: 1},
+	})
+	assert.Equal(slices.Histogram([]float64{-math.MaxFloat64, math.MaxFloat64}, 2), []slices.Bin{
+		{Lower: -math.MaxFloat64, Upper: 0, Count: 1},
+		{Lower: 0, Upper: math.MaxFloat64, Count: 1},
+	})
+	assert.Nil(slices.Histogram([]float64{math.Inf(1), math.NaN()}, 2))
+	assert.Nil(slices.Histogram([]int{}, 2))
+	assert.Nil(slices.Histogram([]int{1, 2}, 0))
+
+	assert.Equal(slices.HistogramWithEdges([]int{-1, 0, 1, 5, 9, 10, 11}, []float64{0, 1, 10}), []slices.Bin{
+		{Lower: 0, Upper: 1, Count: 1},
+		{Lower: 1, Upper: 10, Count: 4},
+	})
+	assert.Nil(slices.HistogramWithEdges([]int{1, 2}, []float64{1}))
+}
+
+// EOF
@@ -0,0 +1,300 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+//--------------------
+// CONSTANTS
+//--------------------
+
+// DefaultCompression is a good compromise between size and accuracy
+// of a TDigest.
+const DefaultCompression = 100.0
+
+// tdigestVersion is the version of the binary encoding.
+const tdigestVersion = 1
+
+//--------------------
+// TDIGEST
+//--------------------
+
+// centroid is a cluster of values represented by their mean.
+type centroid struct {
+	mean   float64
+	weight uint64
+}
+
+// TDigest is a sketch for the estimation of quantiles of huge numbers of
+// values in little memory. It's a merging t-digest after Ted Dunning, which
+// clusters the values into centroids. Those are small at the tails and
+// larger in the middle, so extreme quantiles stay accurate. Sketches of
+// different shards can be merged. A TDigest is not safe for concurrent use.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       uint64
+	min         float64
+	max         float64
+}
+
+// NewTDigest creates an empty sketch. A higher compression leads to more
+// centroids and a higher accuracy. Values below one as well as NaN and
+// infinite values are set to the DefaultCompression.
+func NewTDigest(compression float64) *TDigest {
+	if !isFinite(compression) || compression < 1 {
+		compression = DefaultCompression
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// FeedTDigest adds all values of the slice to the sketch.
+func FeedTDigest[V Number](td *TDigest, ivs []V) {
+	for _, v := range ivs {
+		td.Add(float64(v))
+	}
+}
+
+// FeedTDigestFrom adds all values returned by the iterator to the sketch.
+func FeedTDigestFrom[V Number](td *TDigest, it Iterator[V]) {
+	for v, ok := it(); ok; v, ok = it() {
+		td.Add(float64(v))
+	}
+}
+
+// Add adds a value to the sketch. NaN and infinite values are ignored,
+// as they cannot be interpolated.
+func (td *TDigest) Add(v float64) {
+	if !isFinite(v) {
+		return
+	}
+	td.buffer = append(td.buffer, centroid{v, 1})
+	td.count++
+	td.min = math.Min(td.min, v)
+	td.max = math.Max(td.max, v)
+	if len(td.buffer) >= td.bufferSize() {
+		td.compress()
+	}
+}
+
+// Merge adds all values summarized by the other sketch to this one.
+func (td *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	td.buffer = append(td.buffer, other.centroids...)
+	td.buffer = append(td.buffer, other.buffer...)
+	td.count += other.count
+	td.min = math.Min(td.min, other.min)
+	td.max = math.Max(td.max, other.max)
+	td.compress()
+}
+
+// Count returns the number of added values.
+func (td *TDigest) Count() uint64 {
+	return td.count
+}
+
+// Min returns the lowest added value or NaN if the sketch is empty.
+func (td *TDigest) Min() float64 {
+	if td.count == 0 {
+		return math.NaN()
+	}
+	return td.min
+}
+
+// Max returns the highest added value or NaN if the sketch is empty.
+func (td *TDigest) Max() float64 {
+	if td.count == 0 {
+		return math.NaN()
+	}
+	return td.max
+}
+
+// Quantile returns the estimated q-th quantile with q between 0 and 1.
+// An empty sketch or an invalid q returns NaN.
+func (td *TDigest) Quantile(q float64) float64 {
+	if td.count == 0 || q < 0 || q > 1 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	td.compress()
+	switch {
+	case q == 0:
+		return td.min
+	case q == 1:
+		return td.max
+	case len(td.centroids) == 1:
+		return td.centroids[0].mean
+	}
+	index := q * float64(td.count)
+	// Interpolate between min and the first centroid.
+	first := td.centroids[0]
+	if index < float64(first.weight)/2 {
+		return td.min + (first.mean-td.min)*index/(float64(first.weight)/2)
+	}
+	// Interpolate between the centers of two centroids.
+	center := float64(first.weight) / 2
+	for i := 1; i < len(td.centroids); i++ {
+		prev, curr := td.centroids[i-1], td.centroids[i]
+		next := center + float64(prev.weight)/2 + float64(curr.weight)/2
+		if index < next {
+			return prev.mean + (curr.mean-prev.mean)*(index-center)/(next-center)
+		}
+		center = next
+	}
+	// Interpolate between the last centroid and max.
+	last := td.centroids[len(td.centroids)-1]
+	rest := float64(td.count) - center
+	return last.mean + (td.max-last.mean)*(index-center)/rest
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The compact encoding
+// contains the compression, count, min, max, and the centroids.
+func (td *TDigest) MarshalBinary() ([]byte, error) {
+	td.compress()
+	data := []byte{tdigestVersion}
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(td.compression))
+	data = binary.AppendUvarint(data, td.count)
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(td.min))
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(td.max))
+	data = binary.AppendUvarint(data, uint64(len(td.centroids)))
+	for _, c := range td.centroids {
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(c.mean))
+		data = binary.AppendUvarint(data, c.weight)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Invalid data
+// returns ErrInvalidEncoding. Beside the format the consistency of the
+// sketch is checked, so that later quantiles and merges stay valid.
+func (td *TDigest) UnmarshalBinary(data []byte) error {
+	r := byteReader{data: data}
+	if version, ok := r.byte(); !ok || version != tdigestVersion {
+		return ErrInvalidEncoding
+	}
+	compression, _ := r.float64()
+	count, _ := r.uvarint()
+	lowest, _ := r.float64()
+	highest, _ := r.float64()
+	n, ok := r.uvarint()
+	if !ok || n > uint64(len(data)) {
+		return ErrInvalidEncoding
+	}
+	centroids := make([]centroid, n)
+	for i := range centroids {
+		centroids[i].mean, _ = r.float64()
+		centroids[i].weight, ok = r.uvarint()
+	}
+	if !ok || r.failed || r.pos != len(data) || !isFinite(compression) || compression < 1 {
+		return ErrInvalidEncoding
+	}
+	if count == 0 {
+		if n > 0 {
+			return ErrInvalidEncoding
+		}
+		lowest, highest = math.Inf(1), math.Inf(-1)
+	} else if !validCentroids(centroids, count, lowest, highest) {
+		return ErrInvalidEncoding
+	}
+	*td = TDigest{
+		compression: compression,
+		centroids:   centroids,
+		count:       count,
+		min:         lowest,
+		max:         highest,
+	}
+	return nil
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// validCentroids checks if the centroids have positive weights summing up
+// to count and finite means in ascending order between lowest and highest.
+func validCentroids(centroids []centroid, count uint64, lowest, highest float64) bool {
+	if !isFinite(lowest) || !isFinite(highest) || lowest > highest {
+		return false
+	}
+	sum := uint64(0)
+	prev := lowest
+	for _, c := range centroids {
+		if c.weight == 0 || c.weight > count-sum {
+			return false
+		}
+		if !isFinite(c.mean) || c.mean < prev || c.mean > highest {
+			return false
+		}
+		sum += c.weight
+		prev = c.mean
+	}
+	return sum == count
+}
+
+// bufferSize returns the number of buffered values before compressing.
+func (td *TDigest) bufferSize() int {
+	return int(5 * td.compression)
+}
+
+// compress merges the buffered values into the centroids. The size of each
+// centroid is limited by the arcsine scale function.
+func (td *TDigest) compress() {
+	if len(td.buffer) == 0 {
+		return
+	}
+	less := func(cs []centroid, i, j int) bool {
+		return cs[i].mean < cs[j].mean
+	}
+	all := SortWith(append(td.centroids, td.buffer...), less)
+	total := float64(td.count)
+	merged := make([]centroid, 0, len(td.centroids)+1)
+	curr := all[0]
+	soFar := 0.0
+	limit := td.quantileLimit(0)
+	for _, next := range all[1:] {
+		if (soFar+float64(curr.weight+next.weight))/total <= limit {
+			weight := curr.weight + next.weight
+			curr.mean += (next.mean - curr.mean) * float64(next.weight) / float64(weight)
+			curr.weight = weight
+			continue
+		}
+		merged = append(merged, curr)
+		soFar += float64(curr.weight)
+		limit = td.quantileLimit(soFar / total)
+		curr = next
+	}
+	td.centroids = append(merged, curr)
+	td.buffer = td.buffer[:0]
+}
+
+// quantileLimit returns the highest quantile a centroid starting at
+// quantile q may reach. It's derived from the scale function
+// k(q) = compression / 2π * asin(2q - 1).
+func (td *TDigest) quantileLimit(q float64) float64 {
+	k := td.compression / (2 * math.Pi) * math.Asin(2*q-1)
+	k++
+	if k >= td.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/td.compression) + 1) / 2
+}
+
+// EOF
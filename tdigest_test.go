@@ -0,0 +1,243 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestTDigestQuantiles verifies the estimation of quantiles.
+func TestTDigestQuantiles(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	gen := generators.New(generators.FixedRand())
+	values := slices.Shuffle(slices.Seq(1, 100000, 1))
+	td := slices.NewTDigest(slices.DefaultCompression)
+	slices.FeedTDigest(td, values)
+
+	assert.Equal(td.Count(), uint64(100000))
+	assert.Equal(td.Min(), 1.0)
+	assert.Equal(td.Max(), 100000.0)
+	assert.Equal(td.Quantile(0), 1.0)
+	assert.Equal(td.Quantile(1), 100000.0)
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		assert.Logf("quantile %v", q)
+		assert.About(td.Quantile(q), slices.Quantile(values, q, slices.Linear), 100000*0.005)
+	}
+
+	// Skewed latency like values.
+	latencies := make([]float64, 50000)
+	for i := range latencies {
+		latencies[i] = math.Exp(float64(gen.Int(0, 10000)) / 1000)
+	}
+	td = slices.NewTDigest(slices.DefaultCompression)
+	slices.FeedTDigest(td, latencies)
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		exact := slices.Quantile(latencies, q, slices.Linear)
+		assert.About(td.Quantile(q), exact, exact*0.02)
+	}
+}
+
+// TestTDigestEdgeCases verifies empty and tiny sketches.
+func TestTDigestEdgeCases(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	td := slices.NewTDigest(0)
+	assert.True(math.IsNaN(td.Quantile(0.5)))
+	assert.True(math.IsNaN(td.Min()))
+	assert.True(math.IsNaN(td.Max()))
+
+	td.Add(math.NaN())
+	td.Add(math.Inf(1))
+	td.Add(math.Inf(-1))
+	assert.Equal(td.Count(), uint64(0))
+
+	td.Add(42)
+	assert.Equal(td.Quantile(0.5), 42.0)
+	assert.True(math.IsNaN(td.Quantile(1.5)))
+
+	td.Add(44)
+	assert.About(td.Quantile(0.5), 43.0, 0.0001)
+
+	// Invalid compressions are replaced, so the encoding stays decodable.
+	for _, compression := range []float64{math.Inf(1), math.NaN(), -1} {
+		td = slices.NewTDigest(compression)
+		slices.FeedTDigest(td, []int{1, 2, 3})
+		data, err := td.MarshalBinary()
+		assert.NoError(err)
+		assert.NoError(slices.NewTDigest(0).UnmarshalBinary(data))
+		assert.Equal(td.Quantile(0.5), 2.0)
+	}
+}
+
+// TestTDigestFeedFrom verifies the feeding from an iterator.
+func TestTDigestFeedFrom(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	i := 0
+	it := func() (int, bool) {
+		i++
+		return i, i <= 1000
+	}
+	td := slices.NewTDigest(slices.DefaultCompression)
+	slices.FeedTDigestFrom(td, it)
+
+	assert.Equal(td.Count(), uint64(1000))
+	assert.About(td.Quantile(0.5), 500.5, 5)
+}
+
+// TestTDigestMerge verifies the merging of sketches of shards.
+func TestTDigestMerge(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := slices.Shuffle(slices.Seq(1.0, 30000.0, 1.0))
+	all := slices.NewTDigest(slices.DefaultCompression)
+	for _, shard := range slices.Chunk(values, 10000) {
+		td := slices.NewTDigest(slices.DefaultCompression)
+		slices.FeedTDigest(td, shard)
+		all.Merge(td)
+	}
+	all.Merge(nil)
+	all.Merge(slices.NewTDigest(slices.DefaultCompression))
+
+	assert.Equal(all.Count(), uint64(30000))
+	assert.Equal(all.Min(), 1.0)
+	assert.Equal(all.Max(), 30000.0)
+	for _, q := range []float64{0.01, 0.5, 0.99} {
+		assert.About(all.Quantile(q), slices.Quantile(values, q, slices.Linear), 30000*0.005)
+	}
+}
+
+// TestTDigestEncoding verifies the binary encoding and decoding.
+func TestTDigestEncoding(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	td := slices.NewTDigest(50)
+	slices.FeedTDigest(td, slices.Seq(1, 10000, 1))
+	data, err := td.MarshalBinary()
+	assert.NoError(err)
+	assert.True(len(data) < 2000)
+
+	decoded := slices.NewTDigest(slices.DefaultCompression)
+	err = decoded.UnmarshalBinary(data)
+	assert.NoError(err)
+	assert.Equal(decoded.Count(), td.Count())
+	assert.Equal(decoded.Min(), td.Min())
+	assert.Equal(decoded.Max(), td.Max())
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		assert.Equal(decoded.Quantile(q), td.Quantile(q))
+	}
+
+	// Continue feeding after decoding.
+	slices.FeedTDigest(decoded, []int{10001})
+	assert.Equal(decoded.Count(), uint64(10001))
+
+	// Invalid data.
+	assert.ErrorMatch(decoded.UnmarshalBinary(nil), ".*invalid encoding.*")
+	assert.ErrorMatch(decoded.UnmarshalBinary(data[:len(data)-1]), ".*invalid encoding.*")
+	assert.ErrorMatch(decoded.UnmarshalBinary(append(data, 0)), ".*invalid encoding.*")
+
+	// Empty sketch.
+	data, err = slices.NewTDigest(slices.DefaultCompression).MarshalBinary()
+	assert.NoError(err)
+	assert.NoError(decoded.UnmarshalBinary(data))
+	assert.Equal(decoded.Count(), uint64(0))
+	assert.True(math.IsNaN(decoded.Quantile(0.5)))
+}
+
+// TestTDigestCorruptedEncoding verifies the rejection of well formed but
+// inconsistent encodings.
+func TestTDigestCorruptedEncoding(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	type c struct {
+		mean   float64
+		weight uint64
+	}
+	encode := func(compression float64, count uint64, lowest, highest float64, cs ...c) []byte {
+		data := []byte{1}
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(compression))
+		data = binary.AppendUvarint(data, count)
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(lowest))
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(highest))
+		data = binary.AppendUvarint(data, uint64(len(cs)))
+		for _, c := range cs {
+			data = binary.BigEndian.AppendUint64(data, math.Float64bits(c.mean))
+			data = binary.AppendUvarint(data, c.weight)
+		}
+		return data
+	}
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		descr string
+		data  []byte
+		valid bool
+	}{
+		{"Valid sketch", encode(100, 4, 1, 9, c{1, 1}, c{4, 2}, c{9, 1}), true},
+		{"Equal means", encode(100, 4, 1, 1, c{1, 2}, c{1, 2}), true},
+		{"Empty sketch", encode(100, 0, inf, -inf), true},
+		{"Zero weight", encode(100, 4, 1, 9, c{1, 1}, c{4, 0}, c{5, 2}, c{9, 1}), false},
+		{"Weights not matching count", encode(100, 5, 1, 9, c{1, 1}, c{4, 2}, c{9, 1}), false},
+		{"Overflowing weights", encode(100, 1, 1, 9, c{1, math.MaxUint64}, c{9, 2}), false},
+		{"Means out of order", encode(100, 4, 1, 9, c{1, 1}, c{9, 2}, c{4, 1}), false},
+		{"Mean below min", encode(100, 4, 1, 9, c{0, 1}, c{4, 2}, c{9, 1}), false},
+		{"Mean above max", encode(100, 4, 1, 9, c{1, 1}, c{4, 2}, c{10, 1}), false},
+		{"NaN mean", encode(100, 4, 1, 9, c{1, 1}, c{nan, 2}, c{9, 1}), false},
+		{"Infinite mean", encode(100, 4, 1, inf, c{1, 1}, c{4, 2}, c{inf, 1}), false},
+		{"Min above max", encode(100, 4, 9, 1, c{1, 1}, c{4, 2}, c{9, 1}), false},
+		{"NaN min", encode(100, 4, nan, 9, c{1, 1}, c{4, 2}, c{9, 1}), false},
+		{"Centroids of empty sketch", encode(100, 0, inf, -inf, c{1, 0}), false},
+		{"NaN compression", encode(nan, 4, 1, 9, c{1, 1}, c{4, 2}, c{9, 1}), false},
+		{"Infinite compression", encode(inf, 4, 1, 9, c{1, 1}, c{4, 2}, c{9, 1}), false},
+		{"Low compression", encode(0.5, 4, 1, 9, c{1, 1}, c{4, 2}, c{9, 1}), false},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		td := slices.NewTDigest(slices.DefaultCompression)
+		err := td.UnmarshalBinary(test.data)
+		if test.valid {
+			assert.NoError(err)
+			continue
+		}
+		assert.ErrorMatch(err, ".*invalid encoding.*")
+		assert.Equal(td.Count(), uint64(0))
+	}
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkTDigestAdd runs a performance test on adding values to a sketch.
+func BenchmarkTDigestAdd(b *testing.B) {
+	gen := generators.New(generators.FixedRand())
+	vs := gen.Ints(0, 1000000, 10000)
+	td := slices.NewTDigest(slices.DefaultCompression)
+
+	for i := 0; i < b.N; i++ {
+		td.Add(float64(vs[i%len(vs)]))
+	}
+}
+
+// EOF
@@ -0,0 +1,433 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// CONSTANTS
+//--------------------
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+//--------------------
+// VECTOR
+//--------------------
+
+// Vector is an immutable sequence of values. All modifying methods return
+// a new vector sharing most of its structure with the original one instead
+// of copying all values. Internally it's a balanced tree of pieces, each one
+// a view into a trie with 32 children per node and an extra tail for the
+// last values. So accessing, setting, and appending values takes near
+// constant time, deleting values only splits and joins pieces. The zero
+// value is an empty vector ready to use.
+type Vector[V any] struct {
+	rope *vectorRope[V]
+}
+
+// VectorFromSlice creates a vector containing the values of the slice.
+func VectorFromSlice[V any](ivs []V) Vector[V] {
+	var v Vector[V]
+	return v.Append(ivs...)
+}
+
+// Append returns a vector with the values appended.
+func (v Vector[V]) Append(vs ...V) Vector[V] {
+	if len(vs) == 0 {
+		return v
+	}
+	var last vectorPiece[V]
+	if v.rope != nil {
+		last = v.rope.lastPiece()
+	}
+	for _, value := range vs {
+		if last.end == last.trie.count {
+			last.trie = last.trie.push(value)
+		} else {
+			// Overwrite values behind a subslice.
+			last.trie = last.trie.set(last.end, value)
+		}
+		last.end++
+	}
+	if v.rope == nil {
+		v.rope = last.rope()
+	} else {
+		v.rope = v.rope.withLastPiece(last)
+	}
+	return v
+}
+
+// Delete returns a vector without the value at position i. Positions out
+// of range return the unchanged vector. The values before and behind i
+// are joined as pieces, so no values are copied.
+func (v Vector[V]) Delete(i int) Vector[V] {
+	if i < 0 || i >= v.Len() {
+		return v
+	}
+	head, rest := v.rope.split(i)
+	_, tail := rest.split(1)
+	v.rope = head.join(tail)
+	return v
+}
+
+// Get returns the value at position i. Positions out of range return the
+// default value and false.
+func (v Vector[V]) Get(i int) (V, bool) {
+	if i < 0 || i >= v.Len() {
+		var ov V
+		return ov, false
+	}
+	return v.rope.get(i), true
+}
+
+// Iterator returns an iterator over all values of the vector.
+func (v Vector[V]) Iterator() Iterator[V] {
+	var stack []*vectorRope[V]
+	if v.rope != nil {
+		stack = append(stack, v.rope)
+	}
+	var piece vectorPiece[V]
+	var leaf []V
+	i := 0
+	return func() (V, bool) {
+		for i >= piece.end {
+			if len(stack) == 0 {
+				return exhausted[V]()
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node.left != nil {
+				stack = append(stack, node.right, node.left)
+				continue
+			}
+			piece = node.piece
+			i = piece.start
+			leaf = nil
+		}
+		if leaf == nil || i&vectorMask == 0 {
+			leaf = piece.trie.leafFor(i)
+		}
+		value := leaf[i&vectorMask]
+		i++
+		return value, true
+	}
+}
+
+// Len returns the number of values.
+func (v Vector[V]) Len() int {
+	if v.rope == nil {
+		return 0
+	}
+	return v.rope.length
+}
+
+// Set returns a vector with the value at position i replaced. Positions
+// out of range return the unchanged vector.
+func (v Vector[V]) Set(i int, value V) Vector[V] {
+	if i < 0 || i >= v.Len() {
+		return v
+	}
+	v.rope = v.rope.set(i, value)
+	return v
+}
+
+// Subslice returns the values from fpos to tpos as a new vector in near
+// constant time. The positions are limited like with Subslice(). The
+// new vector keeps the whole structure of the original one alive.
+func (v Vector[V]) Subslice(fpos, tpos int) Vector[V] {
+	if fpos > tpos || fpos >= v.Len() || tpos < 0 {
+		return Vector[V]{}
+	}
+	fpos = between(fpos, 0, v.Len()-1)
+	tpos = between(tpos, 0, v.Len()-1)
+	head, _ := v.rope.split(tpos + 1)
+	_, v.rope = head.split(fpos)
+	return v
+}
+
+// ToSlice returns the values of the vector as a new slice. An empty vector
+// returns nil.
+func (v Vector[V]) ToSlice() []V {
+	return Collect(v.Iterator())
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// vectorPiece is a view on the positions start to end of a trie.
+type vectorPiece[V any] struct {
+	trie  vectorTrie[V]
+	start int
+	end   int
+}
+
+// rope returns a leaf containing the piece or nil if it is empty.
+func (p vectorPiece[V]) rope() *vectorRope[V] {
+	if p.start >= p.end {
+		return nil
+	}
+	return &vectorRope[V]{
+		piece:  p,
+		length: p.end - p.start,
+		height: 1,
+	}
+}
+
+// vectorRope is a persistent AVL tree of pieces. Leaves contain a piece,
+// inner nodes concatenate their left and right children. A nil rope is
+// empty.
+type vectorRope[V any] struct {
+	left   *vectorRope[V]
+	right  *vectorRope[V]
+	piece  vectorPiece[V]
+	length int
+	height int
+}
+
+// newVectorRope creates an inner node concatenating both ropes.
+func newVectorRope[V any](left, right *vectorRope[V]) *vectorRope[V] {
+	height := left.height
+	if right.height > height {
+		height = right.height
+	}
+	return &vectorRope[V]{
+		left:   left,
+		right:  right,
+		length: left.length + right.length,
+		height: height + 1,
+	}
+}
+
+// balanceVectorRope concatenates both ropes and rotates if their heights
+// differ by more than one.
+func balanceVectorRope[V any](left, right *vectorRope[V]) *vectorRope[V] {
+	switch {
+	case left.height > right.height+1:
+		if left.left.height >= left.right.height {
+			return newVectorRope(left.left, newVectorRope(left.right, right))
+		}
+		return newVectorRope(
+			newVectorRope(left.left, left.right.left),
+			newVectorRope(left.right.right, right),
+		)
+	case right.height > left.height+1:
+		if right.right.height >= right.left.height {
+			return newVectorRope(newVectorRope(left, right.left), right.right)
+		}
+		return newVectorRope(
+			newVectorRope(left, right.left.left),
+			newVectorRope(right.left.right, right.right),
+		)
+	}
+	return newVectorRope(left, right)
+}
+
+// join returns a balanced rope concatenating both ropes.
+func (r *vectorRope[V]) join(other *vectorRope[V]) *vectorRope[V] {
+	switch {
+	case r == nil:
+		return other
+	case other == nil:
+		return r
+	case r.height > other.height+1:
+		return balanceVectorRope(r.left, r.right.join(other))
+	case other.height > r.height+1:
+		return balanceVectorRope(r.join(other.left), other.right)
+	}
+	return newVectorRope(r, other)
+}
+
+// split returns the first n values and the rest as two ropes.
+func (r *vectorRope[V]) split(n int) (*vectorRope[V], *vectorRope[V]) {
+	switch {
+	case r == nil:
+		return nil, nil
+	case n <= 0:
+		return nil, r
+	case n >= r.length:
+		return r, nil
+	case r.left == nil:
+		p := r.piece
+		head := vectorPiece[V]{p.trie, p.start, p.start + n}
+		tail := vectorPiece[V]{p.trie, p.start + n, p.end}
+		return head.rope(), tail.rope()
+	case n < r.left.length:
+		head, tail := r.left.split(n)
+		return head, tail.join(r.right)
+	}
+	head, tail := r.right.split(n - r.left.length)
+	return r.left.join(head), tail
+}
+
+// get returns the value at position i.
+func (r *vectorRope[V]) get(i int) V {
+	for r.left != nil {
+		if i < r.left.length {
+			r = r.left
+		} else {
+			i -= r.left.length
+			r = r.right
+		}
+	}
+	return r.piece.trie.get(r.piece.start + i)
+}
+
+// set returns a rope with the value at position i replaced.
+func (r *vectorRope[V]) set(i int, v V) *vectorRope[V] {
+	nr := *r
+	if r.left == nil {
+		nr.piece.trie = r.piece.trie.set(r.piece.start+i, v)
+	} else if i < r.left.length {
+		nr.left = r.left.set(i, v)
+	} else {
+		nr.right = r.right.set(i-r.left.length, v)
+	}
+	return &nr
+}
+
+// lastPiece returns the piece at the end of the rope.
+func (r *vectorRope[V]) lastPiece() vectorPiece[V] {
+	for r.right != nil {
+		r = r.right
+	}
+	return r.piece
+}
+
+// withLastPiece returns a rope with the piece at the end replaced by the
+// not empty piece p.
+func (r *vectorRope[V]) withLastPiece(p vectorPiece[V]) *vectorRope[V] {
+	if r.left == nil {
+		return p.rope()
+	}
+	return newVectorRope(r.left, r.right.withLastPiece(p))
+}
+
+// vectorNode is a node of the trie. Inner nodes have children, leaf nodes
+// have values.
+type vectorNode[V any] struct {
+	children []*vectorNode[V]
+	values   []V
+}
+
+// vectorTrie is the persistent trie containing the values. Only copies of
+// changed paths are created, all other nodes are shared.
+type vectorTrie[V any] struct {
+	count int
+	shift int
+	root  *vectorNode[V]
+	tail  []V
+}
+
+// tailOffset returns the position of the first value in the tail.
+func (t vectorTrie[V]) tailOffset() int {
+	if t.count < vectorWidth {
+		return 0
+	}
+	return ((t.count - 1) >> vectorBits) << vectorBits
+}
+
+// leafFor returns the leaf values containing position i.
+func (t vectorTrie[V]) leafFor(i int) []V {
+	if i >= t.tailOffset() {
+		return t.tail
+	}
+	node := t.root
+	for level := t.shift; level > 0; level -= vectorBits {
+		node = node.children[(i>>level)&vectorMask]
+	}
+	return node.values
+}
+
+// get returns the value at position i.
+func (t vectorTrie[V]) get(i int) V {
+	return t.leafFor(i)[i&vectorMask]
+}
+
+// set returns a trie with the value at position i replaced.
+func (t vectorTrie[V]) set(i int, v V) vectorTrie[V] {
+	if i >= t.tailOffset() {
+		t.tail = Copy(t.tail)
+		t.tail[i&vectorMask] = v
+		return t
+	}
+	t.root = t.setInNode(t.root, t.shift, i, v)
+	return t
+}
+
+// setInNode copies the path to position i and replaces the value.
+func (t vectorTrie[V]) setInNode(node *vectorNode[V], level, i int, v V) *vectorNode[V] {
+	if level == 0 {
+		values := Copy(node.values)
+		values[i&vectorMask] = v
+		return &vectorNode[V]{values: values}
+	}
+	children := Copy(node.children)
+	idx := (i >> level) & vectorMask
+	children[idx] = t.setInNode(children[idx], level-vectorBits, i, v)
+	return &vectorNode[V]{children: children}
+}
+
+// push returns a trie with the value appended.
+func (t vectorTrie[V]) push(v V) vectorTrie[V] {
+	if t.count-t.tailOffset() < vectorWidth {
+		tail := make([]V, len(t.tail), len(t.tail)+1)
+		copy(tail, t.tail)
+		t.tail = append(tail, v)
+		t.count++
+		return t
+	}
+	// Tail is full, move it into the trie.
+	leaf := &vectorNode[V]{values: t.tail}
+	switch {
+	case t.root == nil:
+		t.root = &vectorNode[V]{children: []*vectorNode[V]{leaf}}
+		t.shift = vectorBits
+	case (t.count >> vectorBits) > (1 << t.shift):
+		t.root = &vectorNode[V]{children: []*vectorNode[V]{t.root, newVectorPath(t.shift, leaf)}}
+		t.shift += vectorBits
+	default:
+		t.root = t.pushLeaf(t.root, t.shift, leaf)
+	}
+	t.tail = []V{v}
+	t.count++
+	return t
+}
+
+// pushLeaf copies the path to the position of the new leaf and adds it.
+func (t vectorTrie[V]) pushLeaf(node *vectorNode[V], level int, leaf *vectorNode[V]) *vectorNode[V] {
+	idx := ((t.count - 1) >> level) & vectorMask
+	children := Copy(node.children)
+	var child *vectorNode[V]
+	switch {
+	case level == vectorBits:
+		child = leaf
+	case idx < len(children):
+		child = t.pushLeaf(children[idx], level-vectorBits, leaf)
+	default:
+		child = newVectorPath(level-vectorBits, leaf)
+	}
+	if idx < len(children) {
+		children[idx] = child
+	} else {
+		children = append(children, child)
+	}
+	return &vectorNode[V]{children: children}
+}
+
+// newVectorPath creates a path of inner nodes down to the leaf.
+func newVectorPath[V any](level int, leaf *vectorNode[V]) *vectorNode[V] {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode[V]{children: []*vectorNode[V]{newVectorPath(level-vectorBits, leaf)}}
+}
+
+// EOF
@@ -0,0 +1,228 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+	"tideland.dev/go/audit/generators"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestVectorConversion verifies the conversion from and to slices.
+func TestVectorConversion(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	for _, size := range []int{0, 1, 31, 32, 33, 1024, 1056, 1057, 40000} {
+		assert.Logf("size %d", size)
+		values := slices.Seq(1, size, 1)
+		v := slices.VectorFromSlice(values)
+		assert.Equal(v.Len(), size)
+		assert.Equal(slices.Append(v.ToSlice()), slices.Append(values))
+		for _, i := range []int{0, size / 2, size - 1} {
+			if i >= 0 && i < size {
+				value, ok := v.Get(i)
+				assert.True(ok)
+				assert.Equal(value, values[i])
+			}
+		}
+		_, ok := v.Get(size)
+		assert.False(ok)
+	}
+
+	var empty slices.Vector[int]
+	assert.Equal(empty.Len(), 0)
+	assert.Nil(empty.ToSlice())
+}
+
+// TestVectorPersistence verifies that modifications do not change
+// older versions.
+func TestVectorPersistence(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	v1 := slices.VectorFromSlice(slices.Seq(0, 99, 1))
+	v2 := v1.Set(50, -1)
+	v3 := v2.Append(100, 101)
+	v4 := v3.Delete(0)
+	v5 := v1.Subslice(10, 19)
+	v6 := v5.Append(-2)
+
+	assert.Equal(v1.ToSlice(), slices.Seq(0, 99, 1))
+	assert.Equal(slices.FoldL(v2.ToSlice(), 0, func(v, acc int) int { return acc + v }), 4950-51)
+	assert.Equal(v3.Len(), 102)
+	assert.Equal(v4.Len(), 101)
+	first, _ := v4.Get(0)
+	assert.Equal(first, 1)
+	assert.Equal(v5.ToSlice(), slices.Seq(10, 19, 1))
+	assert.Equal(v6.ToSlice(), append(slices.Seq(10, 19, 1), -2))
+	value, _ := v1.Get(20)
+	assert.Equal(value, 20)
+}
+
+// TestVectorSubslice verifies the limiting of subslice positions.
+func TestVectorSubslice(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := slices.Seq(0, 9, 1)
+	v := slices.VectorFromSlice(values)
+	tests := []struct {
+		descr string
+		fpos  int
+		tpos  int
+	}{
+		{"Inner subslice", 2, 5},
+		{"Negative start", -3, 4},
+		{"Too high end", 5, 20},
+		{"Start behind end", 10, 20},
+		{"End before start", 5, 4},
+		{"End below zero", -5, -1},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(v.Subslice(test.fpos, test.tpos).ToSlice(), slices.Subslice(values, test.fpos, test.tpos))
+	}
+}
+
+// TestVectorOperations verifies random operations against slices.
+func TestVectorOperations(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	gen := generators.New(generators.FixedRand())
+	var v slices.Vector[int]
+	var vs []int
+	for i := 0; i < 5000; i++ {
+		switch op := gen.Int(0, 9); {
+		case op < 5:
+			v = v.Append(i)
+			vs = append(vs, i)
+		case op < 7 && len(vs) > 0:
+			pos := gen.Int(0, len(vs)-1)
+			v = v.Set(pos, -i)
+			vs = slices.ReplaceAt(vs, pos, -i)
+		case op < 8 && len(vs) > 0:
+			pos := gen.Int(0, len(vs)-1)
+			v = v.Delete(pos)
+			vs = slices.Splice(vs, pos, 1)
+		case op < 9 && len(vs) > 0:
+			fpos := gen.Int(0, len(vs)-1)
+			tpos := gen.Int(fpos, len(vs)-1)
+			v = v.Subslice(fpos, tpos)
+			vs = slices.Subslice(vs, fpos, tpos)
+		}
+		assert.Equal(v.Len(), len(vs))
+	}
+	assert.Equal(slices.Append(v.ToSlice()), slices.Append(vs))
+}
+
+// TestVectorDelete verifies the deleting of values joining pieces of
+// the vector.
+func TestVectorDelete(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	values := slices.Seq(0, 1999, 1)
+	v := slices.VectorFromSlice(values)
+	gen := generators.New(generators.FixedRand())
+	versions := []slices.Vector[int]{v}
+	models := [][]int{values}
+	for i := 0; i < 1500; i++ {
+		pos := gen.Int(0, len(values)-1)
+		v = v.Delete(pos)
+		values = slices.Splice(values, pos, 1)
+		if i%3 == 0 {
+			v = v.Append(-i)
+			values = append(slices.Copy(values), -i)
+		}
+		if i%7 == 0 {
+			pos = gen.Int(0, len(values)-1)
+			v = v.Set(pos, i)
+			values = slices.ReplaceAt(values, pos, i)
+		}
+		if i%100 == 0 {
+			versions = append(versions, v)
+			models = append(models, values)
+		}
+	}
+	assert.Equal(v.ToSlice(), values)
+	for i, value := range values {
+		got, ok := v.Get(i)
+		assert.True(ok)
+		assert.Equal(got, value)
+	}
+	assert.Equal(v.Subslice(100, 199).ToSlice(), values[100:200])
+
+	// Former versions are unchanged.
+	for i, version := range versions {
+		assert.Equal(version.ToSlice(), models[i])
+	}
+
+	// Deleting all values.
+	for v.Len() > 0 {
+		v = v.Delete(v.Len() / 2)
+	}
+	assert.Nil(v.ToSlice())
+	assert.Equal(v.Delete(0).Len(), 0)
+	assert.Equal(v.Append(1, 2).ToSlice(), []int{1, 2})
+}
+
+// TestVectorIterator verifies the iterating over a vector.
+func TestVectorIterator(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	v := slices.VectorFromSlice(slices.Seq(1, 100, 1)).Subslice(30, 69)
+	sum := 0
+	it := v.Iterator()
+	for value, ok := it(); ok; value, ok = it() {
+		sum += value
+	}
+	assert.Equal(sum, slices.Sum(slices.Seq(31, 70, 1)))
+}
+
+//--------------------
+// BENCHMARKS AND FUZZ TESTS
+//--------------------
+
+// BenchmarkVectorAppend runs a performance test on appending to a vector.
+func BenchmarkVectorAppend(b *testing.B) {
+	var v slices.Vector[int]
+	for i := 0; i < b.N; i++ {
+		v = v.Append(i)
+	}
+}
+
+// BenchmarkVectorSet runs a performance test on setting values of a vector.
+func BenchmarkVectorSet(b *testing.B) {
+	v := slices.VectorFromSlice(slices.Seq(1, 100000, 1))
+	for i := 0; i < b.N; i++ {
+		v = v.Set(i%100000, i)
+	}
+}
+
+// BenchmarkVectorDelete runs a performance test on deleting values in the
+// middle of a vector.
+func BenchmarkVectorDelete(b *testing.B) {
+	v := slices.VectorFromSlice(slices.Seq(1, 100000, 1))
+	for i := 0; i < b.N; i++ {
+		if v.Len() < 50000 {
+			v = slices.VectorFromSlice(slices.Seq(1, 100000, 1))
+		}
+		v = v.Delete(v.Len() / 2)
+	}
+}
+
+// EOF
@@ -0,0 +1,110 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// WEAVING
+//--------------------
+
+// Interleave merges the slices round-robin, taking one value of each slice
+// in turn. Shorter slices are left out when they are exhausted, so all
+// values are contained in the result.
+func Interleave[V any](ivss ...[]V) []V {
+	if ivss == nil {
+		return nil
+	}
+	n := FlatLength(ivss)
+	longest := 0
+	isNil := true
+	for _, ivs := range ivss {
+		if len(ivs) > longest {
+			longest = len(ivs)
+		}
+		isNil = isNil && ivs == nil
+	}
+	if isNil {
+		return nil
+	}
+	ovs := make([]V, 0, n)
+	for i := 0; i < longest; i++ {
+		for _, ivs := range ivss {
+			if i < len(ivs) {
+				ovs = append(ovs, ivs[i])
+			}
+		}
+	}
+	return ovs
+}
+
+// Intersperse creates a slice mixing a separator between each two values
+// like Join(). The separator is created by fun() out of the values left
+// and right of it.
+func Intersperse[V any](ivs []V, fun func(l, r V) V) []V {
+	if ivs == nil {
+		return nil
+	}
+	ovs := make([]V, 0, 2*len(ivs))
+	for i, v := range ivs {
+		if i > 0 {
+			ovs = append(ovs, fun(ivs[i-1], v))
+		}
+		ovs = append(ovs, v)
+	}
+	return ovs
+}
+
+// JoinSlices concatenates the slices with the values of the separator slice
+// between each two of them, like strings.Join() does for strings.
+func JoinSlices[V any](sep []V, ivss [][]V) []V {
+	if ivss == nil {
+		return nil
+	}
+	n := FlatLength(ivss)
+	if len(ivss) > 1 {
+		n += (len(ivss) - 1) * len(sep)
+	}
+	ovs := make([]V, 0, n)
+	for i, ivs := range ivss {
+		if i > 0 {
+			ovs = append(ovs, sep...)
+		}
+		ovs = append(ovs, ivs...)
+	}
+	return ovs
+}
+
+// Transpose swaps rows and columns of a matrix. The handling of ragged rows
+// is controlled by the policy. Truncate drops the cells behind the shortest
+// row, Pad fills missing cells with default values, and Strict returns
+// ErrLengthMismatch.
+func Transpose[V any](ivss [][]V, policy LengthPolicy) ([][]V, error) {
+	if ivss == nil {
+		return nil, nil
+	}
+	if len(ivss) == 0 {
+		return [][]V{}, nil
+	}
+	ls := make([]int, len(ivss))
+	for i, ivs := range ivss {
+		ls[i] = len(ivs)
+	}
+	l, err := zipLength(policy, ls...)
+	if err != nil {
+		return nil, err
+	}
+	ovss := make([][]V, l)
+	for c := range ovss {
+		ovss[c] = make([]V, len(ivss))
+		for r, ivs := range ivss {
+			ovss[c][r] = valueAt(ivs, c)
+		}
+	}
+	return ovss, nil
+}
+
+// EOF
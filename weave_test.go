@@ -0,0 +1,203 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestInterleave verifies the round-robin merging of slices.
+func TestInterleave(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr  string
+		values [][]int
+		out    []int
+	}{
+		{
+			descr:  "Equal length slices",
+			values: [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}},
+			out:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		}, {
+			descr:  "Unequal length slices",
+			values: [][]int{{1, 3}, {2, 4, 5, 6}, nil, {}},
+			out:    []int{1, 2, 3, 4, 5, 6},
+		}, {
+			descr:  "Single slice",
+			values: [][]int{{1, 2, 3}},
+			out:    []int{1, 2, 3},
+		}, {
+			descr:  "Empty slices",
+			values: [][]int{{}, nil},
+			out:    []int{},
+		}, {
+			descr:  "Nil slices",
+			values: [][]int{nil, nil},
+			out:    nil,
+		}, {
+			descr:  "No slices",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Interleave(test.values...), test.out)
+	}
+}
+
+// TestIntersperse verifies the mixing of generated separators.
+func TestIntersperse(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	mean := func(l, r int) int { return (l + r) / 2 }
+	tests := []struct {
+		descr  string
+		values []int
+		out    []int
+	}{
+		{
+			descr:  "Many values slice",
+			values: []int{2, 4, 8, 16},
+			out:    []int{2, 3, 4, 6, 8, 12, 16},
+		}, {
+			descr:  "Single value slice",
+			values: []int{1},
+			out:    []int{1},
+		}, {
+			descr:  "Empty slice",
+			values: []int{},
+			out:    []int{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.Intersperse(test.values, mean), test.out)
+	}
+}
+
+// TestJoinSlices verifies the joining of slices with a separator slice.
+func TestJoinSlices(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	sep := []string{"-", "-"}
+	tests := []struct {
+		descr  string
+		values [][]string
+		out    []string
+	}{
+		{
+			descr:  "Many slices",
+			values: [][]string{{"a", "b"}, {"c"}, {}, {"d"}},
+			out:    []string{"a", "b", "-", "-", "c", "-", "-", "-", "-", "d"},
+		}, {
+			descr:  "Single slice",
+			values: [][]string{{"a", "b"}},
+			out:    []string{"a", "b"},
+		}, {
+			descr:  "Empty slice",
+			values: [][]string{},
+			out:    []string{},
+		}, {
+			descr:  "Nil slice",
+			values: nil,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		assert.Equal(slices.JoinSlices(sep, test.values), test.out)
+	}
+	assert.Equal(slices.JoinSlices(nil, [][]int{{1}, {2}}), []int{1, 2})
+}
+
+// TestTranspose verifies the swapping of rows and columns.
+func TestTranspose(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ragged := [][]int{{1, 2, 3}, {4, 5}, {6, 7, 8}}
+	tests := []struct {
+		descr  string
+		values [][]int
+		policy slices.LengthPolicy
+		out    [][]int
+		err    error
+	}{
+		{
+			descr:  "Rectangular matrix",
+			values: [][]int{{1, 2, 3}, {4, 5, 6}},
+			policy: slices.Strict,
+			out:    [][]int{{1, 4}, {2, 5}, {3, 6}},
+		}, {
+			descr:  "Ragged matrix truncated",
+			values: ragged,
+			policy: slices.Truncate,
+			out:    [][]int{{1, 4, 6}, {2, 5, 7}},
+		}, {
+			descr:  "Ragged matrix padded",
+			values: ragged,
+			policy: slices.Pad,
+			out:    [][]int{{1, 4, 6}, {2, 5, 7}, {3, 0, 8}},
+		}, {
+			descr:  "Ragged matrix strict",
+			values: ragged,
+			policy: slices.Strict,
+			err:    slices.ErrLengthMismatch,
+		}, {
+			descr:  "Invalid policy",
+			values: ragged,
+			policy: slices.LengthPolicy(42),
+			err:    slices.ErrInvalidPolicy,
+		}, {
+			descr:  "Empty matrix",
+			values: [][]int{},
+			policy: slices.Strict,
+			out:    [][]int{},
+		}, {
+			descr:  "Nil matrix",
+			values: nil,
+			policy: slices.Strict,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		out, err := slices.Transpose(test.values, test.policy)
+		assert.Equal(err, test.err)
+		assert.Equal(out, test.out)
+	}
+
+	// Transposing twice returns the original matrix.
+	matrix := [][]int{{1, 2}, {3, 4}, {5, 6}}
+	once, _ := slices.Transpose(matrix, slices.Strict)
+	twice, _ := slices.Transpose(once, slices.Strict)
+	assert.Equal(twice, matrix)
+}
+
+// EOF
@@ -0,0 +1,187 @@
+// Tideland Go Slices
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"errors"
+)
+
+//--------------------
+// TYPES
+//--------------------
+
+// ErrLengthMismatch is returned if slices have to be of equal length
+// but are not.
+var ErrLengthMismatch = errors.New("slices: length mismatch")
+
+// ErrInvalidPolicy is returned if a LengthPolicy is none of the
+// defined ones.
+var ErrInvalidPolicy = errors.New("slices: invalid length policy")
+
+// Pair contains two values of individual types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple contains three values of individual types.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// LengthPolicy defines how functions working on multiple slices in
+// parallel handle slices of unequal lengths.
+type LengthPolicy int
+
+// Length policies.
+const (
+	// Truncate ends the processing with the shortest slice.
+	Truncate LengthPolicy = iota
+
+	// Pad continues until the longest slice is processed and uses
+	// the zero values of the types for the missing ones. Other pad
+	// values are not supported.
+	Pad
+
+	// Strict returns ErrLengthMismatch for unequal lengths.
+	Strict
+)
+
+//--------------------
+// ZIPPING
+//--------------------
+
+// Zip combines the values of two slices at the same positions to pairs.
+// The handling of unequal lengths is controlled by the policy.
+func Zip[A, B any](as []A, bs []B, policy LengthPolicy) ([]Pair[A, B], error) {
+	return ZipWith(as, bs, policy, func(a A, b B) Pair[A, B] {
+		return Pair[A, B]{a, b}
+	})
+}
+
+// Zip3 combines the values of three slices at the same positions to triples.
+// The handling of unequal lengths is controlled by the policy.
+func Zip3[A, B, C any](as []A, bs []B, cs []C, policy LengthPolicy) ([]Triple[A, B, C], error) {
+	return ZipWith3(as, bs, cs, policy, func(a A, b B, c C) Triple[A, B, C] {
+		return Triple[A, B, C]{a, b, c}
+	})
+}
+
+// ZipWith combines the values of two slices at the same positions with
+// the function fun(). The handling of unequal lengths is controlled by
+// the policy.
+func ZipWith[A, B, O any](as []A, bs []B, policy LengthPolicy, fun func(A, B) O) ([]O, error) {
+	if as == nil && bs == nil {
+		return nil, nil
+	}
+	l, err := zipLength(policy, len(as), len(bs))
+	if err != nil {
+		return nil, err
+	}
+	ovs := make([]O, l)
+	for i := range ovs {
+		ovs[i] = fun(valueAt(as, i), valueAt(bs, i))
+	}
+	return ovs, nil
+}
+
+// ZipWith3 combines the values of three slices at the same positions with
+// the function fun(). The handling of unequal lengths is controlled by
+// the policy.
+func ZipWith3[A, B, C, O any](as []A, bs []B, cs []C, policy LengthPolicy, fun func(A, B, C) O) ([]O, error) {
+	if as == nil && bs == nil && cs == nil {
+		return nil, nil
+	}
+	l, err := zipLength(policy, len(as), len(bs), len(cs))
+	if err != nil {
+		return nil, err
+	}
+	ovs := make([]O, l)
+	for i := range ovs {
+		ovs[i] = fun(valueAt(as, i), valueAt(bs, i), valueAt(cs, i))
+	}
+	return ovs, nil
+}
+
+// Unzip splits a slice of pairs into two slices.
+func Unzip[A, B any](ps []Pair[A, B]) ([]A, []B) {
+	if ps == nil {
+		return nil, nil
+	}
+	as := make([]A, len(ps))
+	bs := make([]B, len(ps))
+	for i, p := range ps {
+		as[i] = p.First
+		bs[i] = p.Second
+	}
+	return as, bs
+}
+
+// Unzip3 splits a slice of triples into three slices.
+func Unzip3[A, B, C any](ts []Triple[A, B, C]) ([]A, []B, []C) {
+	if ts == nil {
+		return nil, nil, nil
+	}
+	as := make([]A, len(ts))
+	bs := make([]B, len(ts))
+	cs := make([]C, len(ts))
+	for i, t := range ts {
+		as[i] = t.First
+		bs[i] = t.Second
+		cs[i] = t.Third
+	}
+	return as, bs, cs
+}
+
+//--------------------
+// PRIVATE
+//--------------------
+
+// zipLength returns the length of the zipped result depending on the policy.
+// An unknown policy returns ErrInvalidPolicy.
+func zipLength(policy LengthPolicy, ls ...int) (int, error) {
+	shortest, longest := ls[0], ls[0]
+	for _, l := range ls[1:] {
+		if l < shortest {
+			shortest = l
+		}
+		if l > longest {
+			longest = l
+		}
+	}
+	switch policy {
+	case Truncate:
+		return shortest, nil
+	case Pad:
+		return longest, nil
+	case Strict:
+		if shortest != longest {
+			return 0, ErrLengthMismatch
+		}
+		return shortest, nil
+	}
+	return 0, ErrInvalidPolicy
+}
+
+// valueAt returns the value at position i or the default value if
+// i is out of range.
+func valueAt[V any](vs []V, i int) V {
+	if i < len(vs) {
+		return vs[i]
+	}
+	var v V
+	return v
+}
+
+// EOF
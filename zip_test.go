@@ -0,0 +1,183 @@
+// Tideland Go Testing - Unit Tests
+//
+// Copyright (C) 2022-2023 Frank Mueller / Tideland / Oldenburg / Germany
+//
+// MatchesAll rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package slices_test // import "tideland.dev/go/slices"
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"fmt"
+	"testing"
+
+	"tideland.dev/go/audit/asserts"
+
+	"tideland.dev/go/slices"
+)
+
+//--------------------
+// TESTS
+//--------------------
+
+// TestZip verifies the zipping of two slices.
+func TestZip(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	tests := []struct {
+		descr   string
+		as      []int
+		bs      []string
+		policy  slices.LengthPolicy
+		out     []slices.Pair[int, string]
+		failing bool
+	}{
+		{
+			descr:  "Equal lengths",
+			as:     []int{1, 2, 3},
+			bs:     []string{"a", "b", "c"},
+			policy: slices.Strict,
+			out:    []slices.Pair[int, string]{{1, "a"}, {2, "b"}, {3, "c"}},
+		}, {
+			descr:  "Truncate unequal lengths",
+			as:     []int{1, 2, 3},
+			bs:     []string{"a", "b"},
+			policy: slices.Truncate,
+			out:    []slices.Pair[int, string]{{1, "a"}, {2, "b"}},
+		}, {
+			descr:  "Pad unequal lengths",
+			as:     []int{1},
+			bs:     []string{"a", "b"},
+			policy: slices.Pad,
+			out:    []slices.Pair[int, string]{{1, "a"}, {0, "b"}},
+		}, {
+			descr:   "Strict unequal lengths",
+			as:      []int{1, 2, 3},
+			bs:      []string{"a", "b"},
+			policy:  slices.Strict,
+			failing: true,
+		}, {
+			descr:  "Truncate with nil slice",
+			as:     []int{1, 2, 3},
+			bs:     nil,
+			policy: slices.Truncate,
+			out:    []slices.Pair[int, string]{},
+		}, {
+			descr:  "Nil slices",
+			as:     nil,
+			bs:     nil,
+			policy: slices.Strict,
+			out:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Logf(test.descr)
+		ps, err := slices.Zip(test.as, test.bs, test.policy)
+		if test.failing {
+			assert.ErrorMatch(err, ".*length mismatch.*")
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(ps, test.out)
+	}
+}
+
+// TestZip3 verifies the zipping of three slices.
+func TestZip3(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	ts, err := slices.Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}, slices.Strict)
+	assert.NoError(err)
+	assert.Equal(ts, []slices.Triple[int, string, bool]{{1, "a", true}, {2, "b", false}})
+
+	ts, err = slices.Zip3([]int{1, 2}, []string{"a"}, []bool{true, false, true}, slices.Pad)
+	assert.NoError(err)
+	assert.Equal(ts, []slices.Triple[int, string, bool]{{1, "a", true}, {2, "", false}, {0, "", true}})
+
+	ts, err = slices.Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}, slices.LengthPolicy(-1))
+	assert.ErrorMatch(err, ".*invalid length policy.*")
+	assert.Nil(ts)
+
+	ts, err = slices.Zip3([]int{1, 2}, []string{"a"}, []bool{true, false, true}, slices.Truncate)
+	assert.NoError(err)
+	assert.Equal(ts, []slices.Triple[int, string, bool]{{1, "a", true}})
+
+	_, err = slices.Zip3([]int{1, 2}, []string{"a"}, []bool{true, false}, slices.Strict)
+	assert.ErrorMatch(err, ".*length mismatch.*")
+}
+
+// TestZipWith verifies the zipping of two slices with a function.
+func TestZipWith(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	fun := func(a int, b string) string { return fmt.Sprintf("%s%d", b, a) }
+
+	vs, err := slices.ZipWith([]int{1, 2, 3}, []string{"a", "b", "c"}, slices.Strict, fun)
+	assert.NoError(err)
+	assert.Equal(vs, []string{"a1", "b2", "c3"})
+
+	vs, err = slices.ZipWith([]int{1, 2, 3}, []string{"a"}, slices.Pad, fun)
+	assert.NoError(err)
+	assert.Equal(vs, []string{"a1", "2", "3"})
+
+	_, err = slices.ZipWith([]int{1, 2, 3}, []string{"a"}, slices.Strict, fun)
+	assert.ErrorMatch(err, ".*length mismatch.*")
+}
+
+// TestZipWith3 verifies the zipping of three slices with a function.
+func TestZipWith3(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	fun := func(a, b, c int) int { return a + b + c }
+
+	vs, err := slices.ZipWith3([]int{1, 2, 3}, []int{10, 20, 30}, []int{100, 200}, slices.Truncate, fun)
+	assert.NoError(err)
+	assert.Equal(vs, []int{111, 222})
+
+	vs, err = slices.ZipWith3([]int{1, 2, 3}, []int{10, 20, 30}, []int{100, 200}, slices.Pad, fun)
+	assert.NoError(err)
+	assert.Equal(vs, []int{111, 222, 33})
+
+	vs, err = slices.ZipWith3[int, int, int, int](nil, nil, nil, slices.Strict, fun)
+	assert.NoError(err)
+	assert.Nil(vs)
+}
+
+// TestUnzip verifies the splitting of pairs into two slices.
+func TestUnzip(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	as, bs := slices.Unzip([]slices.Pair[int, string]{{1, "a"}, {2, "b"}, {3, "c"}})
+	assert.Equal(as, []int{1, 2, 3})
+	assert.Equal(bs, []string{"a", "b", "c"})
+
+	as, bs = slices.Unzip([]slices.Pair[int, string]{})
+	assert.Equal(as, []int{})
+	assert.Equal(bs, []string{})
+
+	as, bs = slices.Unzip[int, string](nil)
+	assert.Nil(as)
+	assert.Nil(bs)
+}
+
+// TestUnzip3 verifies the splitting of triples into three slices.
+func TestUnzip3(t *testing.T) {
+	assert := asserts.NewTesting(t, asserts.FailStop)
+
+	as, bs, cs := slices.Unzip3([]slices.Triple[int, string, bool]{{1, "a", true}, {2, "b", false}})
+	assert.Equal(as, []int{1, 2})
+	assert.Equal(bs, []string{"a", "b"})
+	assert.Equal(cs, []bool{true, false})
+
+	as, bs, cs = slices.Unzip3[int, string, bool](nil)
+	assert.Nil(as)
+	assert.Nil(bs)
+	assert.Nil(cs)
+}
+
+// EOF